@@ -1,6 +1,7 @@
 package config
 
 import (
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -9,11 +10,31 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// API client backends selectable via Config.Client.
+const (
+	ClientHTTP = "http" // talk to the REST API directly (default)
+	ClientCLI  = "cli"  // shell out to `gh api`
+)
+
 // Config holds the application configuration
 type Config struct {
-	Repos                []string `yaml:"repos"`
-	RefreshInterval      int      `yaml:"refresh_interval"` // seconds
-	DefaultPrimaryBranch string   `yaml:"default_branch"`   // repo primary branch for dispatch; default "main"
+	Repos                []string    `yaml:"repos"`             // "owner/repo", or "host/owner/repo" for GitHub Enterprise Server
+	RefreshInterval      int         `yaml:"refresh_interval"`  // seconds
+	DefaultPrimaryBranch string      `yaml:"default_branch"`    // repo primary branch for dispatch; default "main"
+	Client               string      `yaml:"client"`            // ClientHTTP or ClientCLI; default ClientHTTP
+	APIURL               string      `yaml:"api_url"`           // REST API base URL for ClientHTTP; default https://api.github.com
+	MaxRunsPerRepo       int         `yaml:"max_runs_per_repo"` // upper bound on runs paged in per repo; default 500
+	RequestTimeout       int         `yaml:"request_timeout"`   // seconds per API call; default 30
+	Retry                RetryConfig `yaml:"retry"`             // retries of idempotent requests (http client only)
+	DownloadDir          string      `yaml:"download_dir"`      // where artifact zips are saved; default "."
+	DownloadTimeout      int         `yaml:"download_timeout"`  // seconds per artifact download; default 1800
+}
+
+// RetryConfig controls retries of transient API failures.
+type RetryConfig struct {
+	MaxAttempts int `yaml:"max_attempts"`  // total attempts including the first; 1 disables retries; default 3
+	BaseDelayMs int `yaml:"base_delay_ms"` // delay before the first retry, doubled each time; default 500
+	MaxDelayMs  int `yaml:"max_delay_ms"`  // cap on a single delay, including Retry-After; default 30000
 }
 
 // DefaultConfig returns the default configuration
@@ -22,6 +43,16 @@ func DefaultConfig() *Config {
 		Repos:                []string{},
 		RefreshInterval:      2,
 		DefaultPrimaryBranch: "main",
+		Client:               ClientHTTP,
+		MaxRunsPerRepo:       500,
+		RequestTimeout:       30,
+		DownloadDir:          ".",
+		DownloadTimeout:      1800,
+		Retry: RetryConfig{
+			MaxAttempts: 3,
+			BaseDelayMs: 500,
+			MaxDelayMs:  30000,
+		},
 	}
 }
 
@@ -38,6 +69,34 @@ func Load() (*Config, error) {
 		if cfg.DefaultPrimaryBranch == "" {
 			cfg.DefaultPrimaryBranch = "main"
 		}
+		if cfg.Client == "" {
+			cfg.Client = ClientHTTP
+		}
+		if cfg.MaxRunsPerRepo <= 0 {
+			cfg.MaxRunsPerRepo = 500
+		}
+		if cfg.RequestTimeout <= 0 {
+			cfg.RequestTimeout = 30
+		}
+		if cfg.Retry.MaxAttempts <= 0 {
+			cfg.Retry.MaxAttempts = 3
+		}
+		if cfg.Retry.BaseDelayMs <= 0 {
+			cfg.Retry.BaseDelayMs = 500
+		}
+		if cfg.Retry.MaxDelayMs <= 0 {
+			cfg.Retry.MaxDelayMs = 30000
+		}
+		if cfg.DownloadTimeout <= 0 {
+			cfg.DownloadTimeout = 1800
+		}
+		cfg.DownloadDir = expandHome(cfg.DownloadDir)
+		if cfg.DownloadDir == "" {
+			cfg.DownloadDir = "."
+		}
+		for i, repo := range cfg.Repos {
+			cfg.Repos[i] = normalizeRepo(repo)
+		}
 		if len(cfg.Repos) > 0 {
 			return cfg, nil
 		}
@@ -52,6 +111,19 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// expandHome replaces a leading "~/" in path with the user's home directory.
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
+
 // getConfigPath returns the path to the config file
 func getConfigPath() string {
 	home, err := os.UserHomeDir()
@@ -72,23 +144,37 @@ func detectGitRepo() (string, error) {
 	return parseGitRemote(strings.TrimSpace(string(output))), nil
 }
 
-// parseGitRemote extracts owner/repo from a git remote URL
-func parseGitRemote(url string) string {
-	// Handle SSH URLs: git@github.com:owner/repo.git
-	if strings.HasPrefix(url, "git@github.com:") {
-		url = strings.TrimPrefix(url, "git@github.com:")
-		url = strings.TrimSuffix(url, ".git")
-		return url
+// parseGitRemote extracts the repo from a git remote URL: "owner/repo" for
+// github.com, or "host/owner/repo" for any other host, such as a GitHub
+// Enterprise Server.
+func parseGitRemote(remote string) string {
+	var host, path string
+	switch {
+	case strings.Contains(remote, "://"):
+		// https://host/owner/repo.git, ssh://git@host:2222/owner/repo.git
+		u, err := url.Parse(remote)
+		if err != nil {
+			return ""
+		}
+		host, path = u.Hostname(), u.Path
+	case strings.Contains(remote, "@") && strings.Contains(remote, ":"):
+		// scp-like SSH: git@host:owner/repo.git
+		userHost, p, _ := strings.Cut(remote, ":")
+		_, host, _ = strings.Cut(userHost, "@")
+		path = p
+	default:
+		return ""
 	}
 
-	// Handle HTTPS URLs: https://github.com/owner/repo.git
-	if strings.Contains(url, "github.com/") {
-		parts := strings.Split(url, "github.com/")
-		if len(parts) == 2 {
-			repo := strings.TrimSuffix(parts[1], ".git")
-			return repo
-		}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || strings.Count(path, "/") != 1 {
+		return ""
 	}
+	return normalizeRepo(host + "/" + path)
+}
 
-	return ""
+// normalizeRepo drops the redundant "github.com/" prefix from a repo entry so
+// it matches the "owner/repo" names the API reports; other hosts are kept.
+func normalizeRepo(repo string) string {
+	return strings.TrimPrefix(repo, "github.com/")
 }
@@ -11,6 +11,11 @@ func TestParseGitRemote(t *testing.T) {
 		{"https://github.com/owner/repo.git", "owner/repo"},
 		{"https://github.com/owner/repo", "owner/repo"},
 		{"https://github.com/owner/repo-name.git", "owner/repo-name"},
+		{"ssh://git@github.com/owner/repo.git", "owner/repo"},
+		{"git@ghe.example.com:owner/repo.git", "ghe.example.com/owner/repo"},
+		{"https://ghe.example.com/owner/repo.git", "ghe.example.com/owner/repo"},
+		{"ssh://git@ghe.example.com:2222/owner/repo.git", "ghe.example.com/owner/repo"},
+		{"https://ghe.example.com/owner", ""},
 		{"", ""},
 		{"not-a-github-url", ""},
 	}
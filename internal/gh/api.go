@@ -1,25 +1,118 @@
 package gh
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"os/exec"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/x/ansi"
+
 	"github.com/turkosaurus/gh-ci/internal/types"
 )
 
-// Client is the interface for GitHub API operations.
+// Client is the interface for GitHub API operations. Repos are given as
+// "owner/repo", or "host/owner/repo" for GitHub Enterprise Server.
 type Client interface {
-	ListWorkflowRuns(repo string, perPage int) ([]types.WorkflowRun, error)
-	GetJobs(repo string, runID int64) ([]types.Job, error)
-	GetJobLogs(repo string, jobID int64) (string, error)
-	RerunWorkflow(repo string, runID int64, debug bool) error
-	RerunFailedJobs(repo string, runID int64) error
-	CancelWorkflow(repo string, runID int64) error
-	DispatchWorkflow(repo, workflowFile, ref string) error
+	ListWorkflowRuns(ctx context.Context, repo string, opts RunListOptions) (RunsPage, error)
+	ListWorkflowRunsForWorkflow(ctx context.Context, repo, workflowFile string, opts RunListOptions) (RunsPage, error)
+	GetJobs(ctx context.Context, repo string, runID int64) ([]types.Job, error)
+	GetJob(ctx context.Context, repo string, jobID int64) (types.Job, error)
+	GetJobLogs(ctx context.Context, repo string, jobID int64) (string, error)
+	ListArtifacts(ctx context.Context, repo string, runID int64) ([]types.Artifact, error)
+	DownloadArtifact(ctx context.Context, repo string, artifactID int64, w io.Writer) error
+	RerunWorkflow(ctx context.Context, repo string, runID int64, debug bool) error
+	RerunFailedJobs(ctx context.Context, repo string, runID int64, debug bool) error
+	RerunJob(ctx context.Context, repo string, jobID int64, debug bool) error
+	CancelWorkflow(ctx context.Context, repo string, runID int64) error
+	DeleteRun(ctx context.Context, repo string, runID int64) error
+	DeleteRunLogs(ctx context.Context, repo string, runID int64) error
+	ApproveRun(ctx context.Context, repo string, runID int64) error
+	ListPendingDeployments(ctx context.Context, repo string, runID int64) ([]types.PendingDeployment, error)
+	ReviewPendingDeployments(ctx context.Context, repo string, runID int64, environmentIDs []int64, approve bool, comment string) error
+	DispatchWorkflow(ctx context.Context, repo, workflowFile, ref string, inputs map[string]string) error
+	ListWorkflows(ctx context.Context, repo string) ([]types.Workflow, error)
+	EnableWorkflow(ctx context.Context, repo string, workflowID int64) error
+	DisableWorkflow(ctx context.Context, repo string, workflowID int64) error
 	OpenInBrowser(url string) error
+	RateLimit() RateLimit
+}
+
+// MaxPerPage is the largest page size the API accepts.
+const MaxPerPage = 100
+
+// RunListOptions selects a page of workflow runs.
+type RunListOptions struct {
+	PerPage int // results per page, capped at MaxPerPage
+	Page    int // 1-based page number; 0 means the first page
+	Filter  RunFilter
+}
+
+// RunFilter narrows a run listing on the server. Empty fields do not
+// filter.
+type RunFilter struct {
+	Branch  string // head branch
+	Event   string // triggering event, e.g. "push" or "pull_request"
+	Actor   string // login of the user whose push or action created the run
+	Status  string // a status or conclusion, e.g. "in_progress" or "failure"
+	Created string // date range in search syntax, e.g. ">=2024-01-01" or "2024-01-01..2024-01-31"
+	HeadSHA string
+}
+
+// query encodes the options as URL query parameters.
+func (o RunListOptions) query() string {
+	v := url.Values{}
+	v.Set("per_page", strconv.Itoa(o.perPage()))
+	if o.Page > 1 {
+		v.Set("page", strconv.Itoa(o.Page))
+	}
+	for name, value := range map[string]string{
+		"branch":   o.Filter.Branch,
+		"event":    o.Filter.Event,
+		"actor":    o.Filter.Actor,
+		"status":   o.Filter.Status,
+		"created":  o.Filter.Created,
+		"head_sha": o.Filter.HeadSHA,
+	} {
+		if value != "" {
+			v.Set(name, value)
+		}
+	}
+	return v.Encode()
+}
+
+func (o RunListOptions) perPage() int {
+	if o.PerPage <= 0 || o.PerPage > MaxPerPage {
+		return MaxPerPage
+	}
+	return o.PerPage
+}
+
+func (o RunListOptions) page() int {
+	return max(1, o.Page)
+}
+
+// RunsPage is a single page of workflow runs.
+type RunsPage struct {
+	Runs       []types.WorkflowRun
+	TotalCount int // runs matching the query across all pages
+	NextPage   int // page to request next; 0 when there are no more pages
+}
+
+// nextPageFromTotal derives the following page number from total_count,
+// for responses without a Link header.
+func nextPageFromTotal(opts RunListOptions, total, got int) int {
+	if got == 0 || opts.page()*opts.perPage() >= total {
+		return 0
+	}
+	return opts.page() + 1
 }
 
 // CLIClient is the concrete gh-CLI-backed implementation of Client.
@@ -30,26 +123,45 @@ func NewClient() Client {
 	return &CLIClient{}
 }
 
-// ListWorkflowRuns fetches workflow runs for a repository
-func (c *CLIClient) ListWorkflowRuns(repo string, perPage int) ([]types.WorkflowRun, error) {
-	endpoint := fmt.Sprintf("repos/%s/actions/runs?per_page=%d", repo, perPage)
-	output, err := c.apiCall(http.MethodGet, endpoint)
+// ListWorkflowRuns fetches a page of workflow runs for a repository
+func (c *CLIClient) ListWorkflowRuns(ctx context.Context, repo string, opts RunListOptions) (RunsPage, error) {
+	host, repo := SplitHost(repo)
+	return c.listRuns(ctx, host, fmt.Sprintf("repos/%s/actions/runs", repo), opts)
+}
+
+// ListWorkflowRunsForWorkflow fetches a page of the runs of one workflow,
+// given by file name (e.g. "ci.yaml")
+func (c *CLIClient) ListWorkflowRunsForWorkflow(ctx context.Context, repo, workflowFile string, opts RunListOptions) (RunsPage, error) {
+	host, repo := SplitHost(repo)
+	return c.listRuns(ctx, host, fmt.Sprintf("repos/%s/actions/workflows/%s/runs", repo, workflowFile), opts)
+}
+
+// listRuns fetches a page of runs from a runs listing endpoint on host.
+func (c *CLIClient) listRuns(ctx context.Context, host, path string, opts RunListOptions) (RunsPage, error) {
+	endpoint := path + "?" + opts.query()
+	output, err := c.apiCall(ctx, host, http.MethodGet, endpoint)
 	if err != nil {
-		return nil, err
+		return RunsPage{}, err
 	}
 
 	var response types.WorkflowRunsResponse
 	if err := json.Unmarshal(output, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return RunsPage{}, fmt.Errorf("failed to parse response: %w", err)
 	}
+	qualifyRuns(host, response.WorkflowRuns)
 
-	return response.WorkflowRuns, nil
+	return RunsPage{
+		Runs:       response.WorkflowRuns,
+		TotalCount: response.TotalCount,
+		NextPage:   nextPageFromTotal(opts, response.TotalCount, len(response.WorkflowRuns)),
+	}, nil
 }
 
 // GetJobs fetches jobs for a workflow run
-func (c *CLIClient) GetJobs(repo string, runID int64) ([]types.Job, error) {
+func (c *CLIClient) GetJobs(ctx context.Context, repo string, runID int64) ([]types.Job, error) {
+	host, repo := SplitHost(repo)
 	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/jobs", repo, runID)
-	output, err := c.apiCall(http.MethodGet, endpoint)
+	output, err := c.apiCall(ctx, host, http.MethodGet, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -62,75 +174,252 @@ func (c *CLIClient) GetJobs(repo string, runID int64) ([]types.Job, error) {
 	return response.Jobs, nil
 }
 
+// GetJob fetches a single job, e.g. to poll its status
+func (c *CLIClient) GetJob(ctx context.Context, repo string, jobID int64) (types.Job, error) {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/jobs/%d", repo, jobID)
+	output, err := c.apiCall(ctx, host, http.MethodGet, endpoint)
+	if err != nil {
+		return types.Job{}, err
+	}
+
+	var job types.Job
+	if err := json.Unmarshal(output, &job); err != nil {
+		return types.Job{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return job, nil
+}
+
 // GetJobLogs fetches logs for a specific job
-func (c *CLIClient) GetJobLogs(repo string, jobID int64) (string, error) {
+func (c *CLIClient) GetJobLogs(ctx context.Context, repo string, jobID int64) (string, error) {
+	host, repo := SplitHost(repo)
 	endpoint := fmt.Sprintf("repos/%s/actions/jobs/%d/logs", repo, jobID)
-	output, err := c.apiCall(http.MethodGet, endpoint)
+	output, err := c.apiCall(ctx, host, http.MethodGet, endpoint)
 	if err != nil {
 		return "", err
 	}
 	return string(output), nil
 }
 
+// ListArtifacts fetches the artifacts uploaded by a workflow run
+func (c *CLIClient) ListArtifacts(ctx context.Context, repo string, runID int64) ([]types.Artifact, error) {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/artifacts?per_page=%d", repo, runID, MaxPerPage)
+	output, err := c.apiCall(ctx, host, http.MethodGet, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var response types.ArtifactsResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return response.Artifacts, nil
+}
+
+// DownloadArtifact streams an artifact's zip archive into w
+func (c *CLIClient) DownloadArtifact(ctx context.Context, repo string, artifactID int64, w io.Writer) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/artifacts/%d/zip", repo, artifactID)
+	return c.apiStream(ctx, host, http.MethodGet, endpoint, w)
+}
+
 // RerunWorkflow re-runs a workflow, optionally with debug logging enabled
-func (c *CLIClient) RerunWorkflow(repo string, runID int64, debug bool) error {
+func (c *CLIClient) RerunWorkflow(ctx context.Context, repo string, runID int64, debug bool) error {
+	host, repo := SplitHost(repo)
 	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/rerun", repo, runID)
 	var extra []string
 	if debug {
 		extra = []string{"-F", "enable_debug_logging=true"}
 	}
-	_, err := c.apiCall(http.MethodPost, endpoint, extra...)
+	_, err := c.apiCall(ctx, host, http.MethodPost, endpoint, extra...)
 	return err
 }
 
-// RerunFailedJobs re-runs only failed jobs in a workflow
-func (c *CLIClient) RerunFailedJobs(repo string, runID int64) error {
+// RerunFailedJobs re-runs only failed jobs in a workflow and the jobs that
+// depend on them, optionally with debug logging enabled
+func (c *CLIClient) RerunFailedJobs(ctx context.Context, repo string, runID int64, debug bool) error {
+	host, repo := SplitHost(repo)
 	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/rerun-failed-jobs", repo, runID)
-	_, err := c.apiCall(http.MethodPost, endpoint)
+	var extra []string
+	if debug {
+		extra = []string{"-F", "enable_debug_logging=true"}
+	}
+	_, err := c.apiCall(ctx, host, http.MethodPost, endpoint, extra...)
+	return err
+}
+
+// RerunJob re-runs a single job and the jobs that depend on it, optionally
+// with debug logging enabled
+func (c *CLIClient) RerunJob(ctx context.Context, repo string, jobID int64, debug bool) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/jobs/%d/rerun", repo, jobID)
+	var extra []string
+	if debug {
+		extra = []string{"-F", "enable_debug_logging=true"}
+	}
+	_, err := c.apiCall(ctx, host, http.MethodPost, endpoint, extra...)
 	return err
 }
 
 // CancelWorkflow cancels a running workflow
-func (c *CLIClient) CancelWorkflow(repo string, runID int64) error {
+func (c *CLIClient) CancelWorkflow(ctx context.Context, repo string, runID int64) error {
+	host, repo := SplitHost(repo)
 	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/cancel", repo, runID)
-	_, err := c.apiCall(http.MethodPost, endpoint)
+	_, err := c.apiCall(ctx, host, http.MethodPost, endpoint)
+	return err
+}
+
+// DeleteRun deletes a completed workflow run along with its logs and
+// artifacts
+func (c *CLIClient) DeleteRun(ctx context.Context, repo string, runID int64) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d", repo, runID)
+	_, err := c.apiCall(ctx, host, http.MethodDelete, endpoint)
+	return err
+}
+
+// DeleteRunLogs deletes all logs of a workflow run, keeping the run itself
+func (c *CLIClient) DeleteRunLogs(ctx context.Context, repo string, runID int64) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/logs", repo, runID)
+	_, err := c.apiCall(ctx, host, http.MethodDelete, endpoint)
+	return err
+}
+
+// ApproveRun lets a workflow run from a fork pull request proceed
+func (c *CLIClient) ApproveRun(ctx context.Context, repo string, runID int64) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/approve", repo, runID)
+	_, err := c.apiCall(ctx, host, http.MethodPost, endpoint)
 	return err
 }
 
+// ListPendingDeployments fetches the environments a waiting run needs
+// approval to deploy to
+func (c *CLIClient) ListPendingDeployments(ctx context.Context, repo string, runID int64) ([]types.PendingDeployment, error) {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/pending_deployments", repo, runID)
+	output, err := c.apiCall(ctx, host, http.MethodGet, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployments []types.PendingDeployment
+	if err := json.Unmarshal(output, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return deployments, nil
+}
+
+// ReviewPendingDeployments approves or rejects a waiting run's deployments
+// to the given environments
+func (c *CLIClient) ReviewPendingDeployments(ctx context.Context, repo string, runID int64, environmentIDs []int64, approve bool, comment string) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/pending_deployments", repo, runID)
+	args := []string{"-f", "state=" + reviewState(approve), "-f", "comment=" + comment}
+	for _, id := range environmentIDs {
+		args = append(args, "-F", fmt.Sprintf("environment_ids[]=%d", id))
+	}
+	_, err := c.apiCall(ctx, host, http.MethodPost, endpoint, args...)
+	return err
+}
+
+// reviewState is the pending deployment review state for a decision.
+func reviewState(approve bool) string {
+	if approve {
+		return "approved"
+	}
+	return "rejected"
+}
+
 // DispatchWorkflow triggers a workflow_dispatch event on the given ref.
-// workflowFile is the filename, e.g. "ci.yaml".
-func (c *CLIClient) DispatchWorkflow(repo, workflowFile, ref string) error {
+// workflowFile is the filename, e.g. "ci.yaml"; inputs are the
+// workflow_dispatch input values, sent as strings.
+func (c *CLIClient) DispatchWorkflow(ctx context.Context, repo, workflowFile, ref string, inputs map[string]string) error {
+	host, repo := SplitHost(repo)
 	endpoint := fmt.Sprintf("repos/%s/actions/workflows/%s/dispatches", repo, workflowFile)
-	_, err := c.apiCall(http.MethodPost, endpoint, "-f", "ref="+ref)
+	args := []string{"-f", "ref=" + ref}
+	for name, value := range inputs {
+		args = append(args, "-f", fmt.Sprintf("inputs[%s]=%s", name, value))
+	}
+	_, err := c.apiCall(ctx, host, http.MethodPost, endpoint, args...)
+	return err
+}
+
+// ListWorkflows fetches the workflows registered in a repository, with
+// their enabled/disabled state
+func (c *CLIClient) ListWorkflows(ctx context.Context, repo string) ([]types.Workflow, error) {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/workflows?per_page=%d", repo, MaxPerPage)
+	output, err := c.apiCall(ctx, host, http.MethodGet, endpoint)
 	if err != nil {
-		msg := err.Error()
-		lower := strings.ToLower(msg)
-		if strings.Contains(lower, "404") ||
-			strings.Contains(lower, "not found") ||
-			strings.Contains(lower, "no workflow") ||
-			strings.Contains(lower, "could not find") {
-			return fmt.Errorf("%s\nhint: workflow file must exist on the default branch to be dispatched", msg)
-		}
-		return err
+		return nil, err
 	}
-	return nil
+
+	var response types.WorkflowsResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return response.Workflows, nil
+}
+
+// EnableWorkflow lets a disabled workflow's triggers start runs again
+func (c *CLIClient) EnableWorkflow(ctx context.Context, repo string, workflowID int64) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/workflows/%d/enable", repo, workflowID)
+	_, err := c.apiCall(ctx, host, http.MethodPut, endpoint)
+	return err
+}
+
+// DisableWorkflow stops a workflow's triggers from starting runs
+func (c *CLIClient) DisableWorkflow(ctx context.Context, repo string, workflowID int64) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/workflows/%d/disable", repo, workflowID)
+	_, err := c.apiCall(ctx, host, http.MethodPut, endpoint)
+	return err
 }
 
 // OpenInBrowser opens a URL in the default browser
 func (c *CLIClient) OpenInBrowser(url string) error {
-	cmd := exec.Command("gh", "browse", "--url", url)
+	return openBrowser(url)
+}
+
+// RateLimit is not tracked by the gh CLI backend; it always reports an
+// unknown quota.
+func (c *CLIClient) RateLimit() RateLimit {
+	return RateLimit{}
+}
+
+// openBrowser opens a URL in the default browser
+func openBrowser(url string) error {
 	// Use open command on macOS, xdg-open on Linux
-	cmd = exec.Command("open", url)
+	cmd := exec.Command("open", url)
 	return cmd.Start()
 }
 
-// apiCall makes an API call using the gh CLI
-func (c *CLIClient) apiCall(method, endpoint string, extraArgs ...string) ([]byte, error) {
-	args := append([]string{"api", "-X", method, endpoint}, extraArgs...)
-	cmd := exec.Command("gh", args...)
+// apiCall makes an API call to host using the gh CLI; the gh process is
+// killed if ctx is done before it exits.
+func (c *CLIClient) apiCall(ctx context.Context, host, method, endpoint string, extraArgs ...string) ([]byte, error) {
+	args := []string{"api", "-X", method, endpoint}
+	if host != DefaultHost {
+		args = append(args, "--hostname", host)
+	}
+	args = append(args, extraArgs...)
+	cmd := exec.CommandContext(ctx, "gh", args...)
 	output, err := cmd.Output()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, fmt.Errorf("gh api %s: %w", endpoint, ctxErr)
+	}
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
+			if apiErr := parseCLIError(method, endpoint, output, exitErr.Stderr); apiErr != nil {
+				return nil, apiErr
+			}
 			return nil, fmt.Errorf("gh api error: %s", string(exitErr.Stderr))
 		}
 		return nil, fmt.Errorf("failed to execute gh: %w", err)
@@ -138,11 +427,83 @@ func (c *CLIClient) apiCall(method, endpoint string, extraArgs ...string) ([]byt
 	return output, nil
 }
 
+// apiStream is apiCall for large responses: the body is written to w as it
+// arrives instead of being buffered. On failure w may hold part of the
+// body, or the error response.
+func (c *CLIClient) apiStream(ctx context.Context, host, method, endpoint string, w io.Writer) error {
+	args := []string{"api", "-X", method, endpoint}
+	if host != DefaultHost {
+		args = append(args, "--hostname", host)
+	}
+	cmd := exec.CommandContext(ctx, "gh", args...)
+	head := &headBuffer{max: 4096} // the start of an error response, for parseCLIError
+	var stderr bytes.Buffer
+	cmd.Stdout = io.MultiWriter(w, head)
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("gh api %s: %w", endpoint, ctxErr)
+	}
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			if apiErr := parseCLIError(method, endpoint, head.Bytes(), stderr.Bytes()); apiErr != nil {
+				return apiErr
+			}
+			return fmt.Errorf("gh api error: %s", stderr.String())
+		}
+		return fmt.Errorf("failed to execute gh: %w", err)
+	}
+	return nil
+}
+
+// headBuffer keeps the first max bytes written to it and discards the rest.
+type headBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *headBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.Len(); room > 0 {
+		b.Buffer.Write(p[:min(room, len(p))])
+	}
+	return len(p), nil
+}
+
+// cliStatusRe matches the status suffix gh prints on failed requests,
+// e.g. "gh: Not Found (HTTP 404)".
+var cliStatusRe = regexp.MustCompile(`\(HTTP (\d{3})\)`)
+
+// parseCLIError recovers an *APIError from a failed `gh api` invocation,
+// which writes the response body to stdout and the status to stderr.
+// It returns nil when stderr carries no HTTP status.
+func parseCLIError(method, endpoint string, stdout, stderr []byte) *APIError {
+	match := cliStatusRe.FindSubmatch(stderr)
+	if match == nil {
+		return nil
+	}
+	status, _ := strconv.Atoi(string(match[1]))
+	return newAPIError(method, endpoint, status, stdout)
+}
+
 // ParseRepoFromRun extracts the repo identifier from a workflow run
 func ParseRepoFromRun(run types.WorkflowRun) string {
 	return run.Repository.FullName
 }
 
+// FormatSize formats a byte count in a human-readable way
+func FormatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for q := n / unit; q >= unit; q /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 // FormatDuration formats a duration in a human-readable way
 func FormatDuration(d int64) string {
 	seconds := d
@@ -159,19 +520,26 @@ func FormatDuration(d int64) string {
 	return fmt.Sprintf("%dh %dm", hours, minutes)
 }
 
-// TruncateString truncates a string to a maximum length
+// TruncateString truncates a string to a maximum width in terminal columns,
+// ending in "..." when there is room for it. Wide characters count for
+// their width, and are never cut in two.
 func TruncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
 	if maxLen <= 3 {
-		return s[:maxLen]
+		return ansi.Truncate(s, maxLen, "")
 	}
-	return s[:maxLen-3] + "..."
+	return ansi.Truncate(s, maxLen, "...")
+}
+
+// PadString truncates s as TruncateString does and pads it with spaces to
+// exactly width columns, for aligned columns of text.
+func PadString(s string, width int) string {
+	s = TruncateString(s, width)
+	return s + strings.Repeat(" ", max(0, width-ansi.StringWidth(s)))
 }
 
-// SplitRepo splits a repo string into owner and name
+// SplitRepo splits a repo string into owner and name, dropping any host
 func SplitRepo(repo string) (owner, name string) {
+	_, repo = SplitHost(repo)
 	parts := strings.SplitN(repo, "/", 2)
 	if len(parts) != 2 {
 		return "", repo
@@ -3,6 +3,7 @@
 package gh
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -36,14 +37,19 @@ func TestCLIClientListWorkflowRuns(t *testing.T) {
 	repo := testRepo(t)
 	client := NewClient()
 
-	runs, err := client.ListWorkflowRuns(repo, 5)
+	page, err := client.ListWorkflowRuns(context.Background(), repo, RunListOptions{PerPage: 5})
 	if err != nil {
 		t.Fatalf("ListWorkflowRuns(%q, 5) error: %v", repo, err)
 	}
+	runs := page.Runs
 
 	// result must be the right type (may be empty)
 	_ = []types.WorkflowRun(runs)
 
+	if len(runs) == 5 && page.TotalCount > 5 && page.NextPage != 2 {
+		t.Errorf("NextPage = %d, want 2 (total_count %d)", page.NextPage, page.TotalCount)
+	}
+
 	if len(runs) > 0 {
 		if runs[0].ID <= 0 {
 			t.Errorf("runs[0].ID = %d, want > 0", runs[0].ID)
@@ -58,15 +64,16 @@ func TestCLIClientGetJobs(t *testing.T) {
 	repo := testRepo(t)
 	client := NewClient()
 
-	runs, err := client.ListWorkflowRuns(repo, 5)
+	page, err := client.ListWorkflowRuns(context.Background(), repo, RunListOptions{PerPage: 5})
 	if err != nil {
 		t.Fatalf("ListWorkflowRuns(%q, 5) error: %v", repo, err)
 	}
+	runs := page.Runs
 	if len(runs) == 0 {
 		t.Skip("no workflow runs found in repo")
 	}
 
-	jobs, err := client.GetJobs(repo, runs[0].ID)
+	jobs, err := client.GetJobs(context.Background(), repo, runs[0].ID)
 	if err != nil {
 		t.Fatalf("GetJobs(%q, %d) error: %v", repo, runs[0].ID, err)
 	}
@@ -82,17 +89,18 @@ func TestCLIClientGetJobLogs(t *testing.T) {
 	repo := testRepo(t)
 	client := NewClient()
 
-	runs, err := client.ListWorkflowRuns(repo, 5)
+	page, err := client.ListWorkflowRuns(context.Background(), repo, RunListOptions{PerPage: 5})
 	if err != nil {
 		t.Fatalf("ListWorkflowRuns(%q, 5) error: %v", repo, err)
 	}
+	runs := page.Runs
 	if len(runs) == 0 {
 		t.Skip("no workflow runs found in repo")
 	}
 
 	var completedJob *types.Job
 	for i := range runs {
-		jobs, err := client.GetJobs(repo, runs[i].ID)
+		jobs, err := client.GetJobs(context.Background(), repo, runs[i].ID)
 		if err != nil {
 			continue
 		}
@@ -110,7 +118,7 @@ func TestCLIClientGetJobLogs(t *testing.T) {
 		t.Skip("no completed jobs found in recent runs")
 	}
 
-	logs, err := client.GetJobLogs(repo, completedJob.ID)
+	logs, err := client.GetJobLogs(context.Background(), repo, completedJob.ID)
 	if err != nil {
 		t.Fatalf("GetJobLogs(%q, %d) error: %v", repo, completedJob.ID, err)
 	}
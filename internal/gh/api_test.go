@@ -1,6 +1,9 @@
 package gh
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+)
 
 func TestFormatDuration(t *testing.T) {
 	tests := []struct {
@@ -23,6 +26,25 @@ func TestFormatDuration(t *testing.T) {
 	}
 }
 
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{5 * 1024 * 1024, "5.0 MB"},
+	}
+	for _, tt := range tests {
+		got := FormatSize(tt.n)
+		if got != tt.want {
+			t.Errorf("FormatSize(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
 func TestTruncateString(t *testing.T) {
 	tests := []struct {
 		s      string
@@ -35,6 +57,9 @@ func TestTruncateString(t *testing.T) {
 		{"hi", 2, "hi"},
 		{"hello", 3, "hel"},
 		{"abcdef", 4, "a..."},
+		{"héllo wörld", 8, "héllo..."},
+		{"ビルドとテスト", 9, "ビルド..."}, // two columns per character
+		{"✗ ビルド", 6, "✗ ..."},    // ビ would not fit whole
 	}
 	for _, tt := range tests {
 		got := TruncateString(tt.s, tt.maxLen)
@@ -44,6 +69,23 @@ func TestTruncateString(t *testing.T) {
 	}
 }
 
+func TestPadString(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"ci", 4, "ci  "},
+		{"ビルド", 8, "ビルド  "},
+		{"ビルドとテスト", 8, "ビル... "},
+	}
+	for _, tt := range tests {
+		if got := PadString(tt.s, tt.width); got != tt.want {
+			t.Errorf("PadString(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
+
 func TestSplitRepo(t *testing.T) {
 	tests := []struct {
 		repo      string
@@ -54,6 +96,7 @@ func TestSplitRepo(t *testing.T) {
 		{"just-repo", "", "just-repo"},
 		{"", "", ""},
 		{"org/my-repo", "org", "my-repo"},
+		{"ghe.example.com/org/my-repo", "org", "my-repo"},
 	}
 	for _, tt := range tests {
 		owner, name := SplitRepo(tt.repo)
@@ -63,3 +106,89 @@ func TestSplitRepo(t *testing.T) {
 		}
 	}
 }
+
+func TestNextPageFromTotal(t *testing.T) {
+	tests := []struct {
+		opts  RunListOptions
+		total int
+		got   int
+		want  int
+	}{
+		{RunListOptions{PerPage: 10}, 25, 10, 2},
+		{RunListOptions{PerPage: 10, Page: 2}, 25, 10, 3},
+		{RunListOptions{PerPage: 10, Page: 3}, 25, 5, 0},
+		{RunListOptions{PerPage: 10}, 10, 10, 0},
+		{RunListOptions{PerPage: 10}, 25, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := nextPageFromTotal(tt.opts, tt.total, tt.got); got != tt.want {
+			t.Errorf("nextPageFromTotal(%+v, %d, %d) = %d, want %d", tt.opts, tt.total, tt.got, got, tt.want)
+		}
+	}
+}
+
+func TestRunListOptionsQuery(t *testing.T) {
+	tests := []struct {
+		opts RunListOptions
+		want string
+	}{
+		{RunListOptions{}, "per_page=100"},
+		{RunListOptions{PerPage: 20, Page: 3}, "page=3&per_page=20"},
+		{RunListOptions{PerPage: 20, Filter: RunFilter{Branch: "feat/x", Status: "failure"}}, "branch=feat%2Fx&per_page=20&status=failure"},
+		{RunListOptions{PerPage: 20, Filter: RunFilter{Event: "push", Actor: "octocat", Created: ">=2024-01-01", HeadSHA: "abc123"}},
+			"actor=octocat&created=%3E%3D2024-01-01&event=push&head_sha=abc123&per_page=20"},
+	}
+	for _, tt := range tests {
+		if got := tt.opts.query(); got != tt.want {
+			t.Errorf("query(%+v) = %q, want %q", tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestParseCLIError(t *testing.T) {
+	stdout := []byte(`{"message":"Not Found","documentation_url":"https://docs.github.com/rest"}`)
+	stderr := []byte("gh: Not Found (HTTP 404)\n")
+
+	apiErr := parseCLIError("GET", "repos/o/r/actions/runs", stdout, stderr)
+	if apiErr == nil {
+		t.Fatal("parseCLIError returned nil")
+	}
+	if apiErr.StatusCode != 404 || apiErr.Message != "Not Found" || apiErr.DocumentationURL != "https://docs.github.com/rest" {
+		t.Errorf("parseCLIError = %+v", apiErr)
+	}
+	if !IsNotFound(apiErr) {
+		t.Errorf("IsNotFound(%v) = false", apiErr)
+	}
+
+	if got := parseCLIError("GET", "x", nil, []byte("could not resolve host")); got != nil {
+		t.Errorf("parseCLIError without status = %+v, want nil", got)
+	}
+}
+
+func TestErrorHelpers(t *testing.T) {
+	tests := []struct {
+		err                             error
+		notFound, forbidden, gone, rate bool
+	}{
+		{newAPIError("GET", "x", 404, nil), true, false, false, false},
+		{newAPIError("GET", "x", 403, []byte(`{"message":"Resource not accessible by integration"}`)), false, true, false, false},
+		{newAPIError("GET", "x", 403, []byte(`{"message":"You have exceeded a secondary rate limit"}`)), false, false, false, true},
+		{newAPIError("GET", "x", 429, nil), false, false, false, true},
+		{fmt.Errorf("wrapped: %w", newAPIError("GET", "x", 410, nil)), false, false, true, false},
+		{fmt.Errorf("plain error"), false, false, false, false},
+	}
+	for _, tt := range tests {
+		if got := IsNotFound(tt.err); got != tt.notFound {
+			t.Errorf("IsNotFound(%v) = %v", tt.err, got)
+		}
+		if got := IsForbidden(tt.err); got != tt.forbidden {
+			t.Errorf("IsForbidden(%v) = %v", tt.err, got)
+		}
+		if got := IsGone(tt.err); got != tt.gone {
+			t.Errorf("IsGone(%v) = %v", tt.err, got)
+		}
+		if got := IsRateLimited(tt.err); got != tt.rate {
+			t.Errorf("IsRateLimited(%v) = %v", tt.err, got)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package gh
+
+import (
+	"log/slog"
+	"net/http"
+	"sync"
+)
+
+// maxCacheEntries bounds the number of endpoints remembered by responseCache;
+// the oldest entry is evicted first.
+const maxCacheEntries = 256
+
+// cacheEntry holds the validators, body and headers of a GET response. The
+// raw body is kept rather than the decoded value, so every hit decodes a
+// value of its own that callers are free to modify.
+type cacheEntry struct {
+	etag         string
+	lastModified string
+	body         []byte
+	header       http.Header
+}
+
+// responseCache remembers ETag/Last-Modified validators per endpoint so
+// unchanged resources can be revalidated with a conditional request, and a
+// 304 Not Modified answered from the previous body.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	order   []string // insertion order, for eviction
+	hits    int
+	misses  int
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string]cacheEntry)}
+}
+
+func (c *responseCache) get(key string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	return e, ok
+}
+
+func (c *responseCache) put(key string, e cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok {
+		c.order = append(c.order, key)
+		if len(c.order) > maxCacheEntries {
+			delete(c.entries, c.order[0])
+			c.order = c.order[1:]
+		}
+	}
+	c.entries[key] = e
+}
+
+// record counts a lookup as a hit or miss and logs the running totals.
+func (c *responseCache) record(key string, hit bool) {
+	c.mu.Lock()
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	hits, misses := c.hits, c.misses
+	c.mu.Unlock()
+	slog.Debug("etag cache", "endpoint", key, "hit", hit, "hits", hits, "misses", misses)
+}
+
+// stats returns the number of cache hits and misses so far.
+func (c *responseCache) stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
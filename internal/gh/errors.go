@@ -0,0 +1,86 @@
+package gh
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// APIError is a REST API call that completed with a 4xx or 5xx status.
+type APIError struct {
+	StatusCode       int
+	Message          string // GitHub's "message" field, or the raw body
+	DocumentationURL string // GitHub's "documentation_url" field, if any
+	Method           string
+	Endpoint         string        // path relative to the API root, e.g. "repos/o/r/actions/runs"
+	RateLimited      bool          // quota exhausted or secondary rate limit hit
+	RetryAfter       time.Duration // from the Retry-After header; 0 when absent
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s: %s (HTTP %d)", e.Method, e.Endpoint, e.Message, e.StatusCode)
+}
+
+// newAPIError builds an APIError from a failed response body, which GitHub
+// sends as {"message": ..., "documentation_url": ...}.
+func newAPIError(method, endpoint string, status int, body []byte) *APIError {
+	var payload struct {
+		Message          string `json:"message"`
+		DocumentationURL string `json:"documentation_url"`
+	}
+	_ = json.Unmarshal(body, &payload)
+	msg := payload.Message
+	if msg == "" {
+		msg = strings.TrimSpace(string(body))
+	}
+	if msg == "" {
+		msg = http.StatusText(status)
+	}
+	e := &APIError{
+		StatusCode:       status,
+		Message:          msg,
+		DocumentationURL: payload.DocumentationURL,
+		Method:           method,
+		Endpoint:         endpoint,
+	}
+	if status == http.StatusTooManyRequests ||
+		(status == http.StatusForbidden && strings.Contains(strings.ToLower(msg), "rate limit")) {
+		e.RateLimited = true
+	}
+	return e
+}
+
+// statusOf returns the HTTP status of an *APIError in err's chain, or 0.
+func statusOf(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is a 404 from the API.
+func IsNotFound(err error) bool {
+	return statusOf(err) == http.StatusNotFound
+}
+
+// IsForbidden reports whether err is a 403 from the API that is not caused
+// by rate limiting.
+func IsForbidden(err error) bool {
+	return statusOf(err) == http.StatusForbidden && !IsRateLimited(err)
+}
+
+// IsGone reports whether err is a 410 from the API, e.g. for expired logs.
+func IsGone(err error) bool {
+	return statusOf(err) == http.StatusGone
+}
+
+// IsRateLimited reports whether err was caused by the primary or secondary
+// rate limit.
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.RateLimited
+}
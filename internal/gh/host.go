@@ -0,0 +1,86 @@
+package gh
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/turkosaurus/gh-ci/internal/types"
+)
+
+// DefaultHost is the GitHub host assumed for repos given as "owner/repo".
+const DefaultHost = "github.com"
+
+// SplitHost separates the host from a repo given as "host/owner/repo", as
+// used for GitHub Enterprise Server. Repos given as "owner/repo" are on
+// DefaultHost.
+func SplitHost(repo string) (host, ownerRepo string) {
+	if strings.Count(repo, "/") == 2 {
+		host, ownerRepo, _ = strings.Cut(repo, "/")
+		return host, ownerRepo
+	}
+	return DefaultHost, repo
+}
+
+// qualifyRepo is the inverse of SplitHost: it prefixes ownerRepo with host
+// unless host is DefaultHost.
+func qualifyRepo(host, ownerRepo string) string {
+	if host == DefaultHost {
+		return ownerRepo
+	}
+	return host + "/" + ownerRepo
+}
+
+// qualifyRuns rewrites each run's Repository.FullName to include host, so
+// the name can be passed back to a Client to reach the same server. The head
+// repository is qualified too so the two stay comparable.
+func qualifyRuns(host string, runs []types.WorkflowRun) {
+	if host == DefaultHost {
+		return
+	}
+	for i := range runs {
+		runs[i].Repository.FullName = qualifyRepo(host, runs[i].Repository.FullName)
+		if head := runs[i].HeadRepository.FullName; head != "" {
+			runs[i].HeadRepository.FullName = qualifyRepo(host, head)
+		}
+	}
+}
+
+// APIBaseURL returns the REST API root for host: DefaultBaseURL for
+// github.com, or the /api/v3 endpoint of a GitHub Enterprise Server.
+func APIBaseURL(host string) string {
+	if host == DefaultHost {
+		return DefaultBaseURL
+	}
+	return "https://" + host + "/api/v3"
+}
+
+// RepoURL returns the web URL of repo, which may be host-qualified.
+func RepoURL(repo string) string {
+	host, ownerRepo := SplitHost(repo)
+	return "https://" + host + "/" + ownerRepo
+}
+
+// ResolveHostToken returns an API token for host. github.com uses
+// ResolveToken; other hosts use GH_ENTERPRISE_TOKEN or
+// GITHUB_ENTERPRISE_TOKEN, falling back to `gh auth token --hostname`.
+func ResolveHostToken(host string) (string, error) {
+	if host == DefaultHost {
+		return ResolveToken()
+	}
+	for _, env := range []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"} {
+		if token := os.Getenv(env); token != "" {
+			return token, nil
+		}
+	}
+	output, err := exec.Command("gh", "auth", "token", "--hostname", host).Output()
+	if err != nil {
+		return "", fmt.Errorf("gh auth token --hostname %s: %w", host, err)
+	}
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", fmt.Errorf("gh auth token --hostname %s: empty token", host)
+	}
+	return token, nil
+}
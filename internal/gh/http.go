@@ -0,0 +1,592 @@
+package gh
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/turkosaurus/gh-ci/internal/types"
+)
+
+// DefaultBaseURL is the REST API root for github.com.
+const DefaultBaseURL = "https://api.github.com"
+
+// HTTPClient is a net/http-backed implementation of Client that talks to the
+// GitHub REST API directly instead of forking a gh process per request.
+type HTTPClient struct {
+	baseURL string // API root for DefaultHost
+	token   string // token for DefaultHost
+	http    *http.Client
+	cache   *responseCache
+
+	// resolveToken finds tokens for GitHub Enterprise Server hosts; results,
+	// failures included, are kept in tokens. tokensMu is held while a token
+	// is resolved, so each host is looked up once, and is separate from mu
+	// so a slow lookup does not block RateLimit.
+	resolveToken func(host string) (string, error)
+	tokensMu     sync.Mutex
+	tokens       map[string]hostToken
+
+	retry  RetryPolicy
+	sleep  func(ctx context.Context, d time.Duration) error
+	jitter func(d time.Duration) time.Duration
+
+	mu   sync.Mutex
+	rate RateLimit
+}
+
+// NewHTTPClient creates a new GitHub API client for the REST API rooted at
+// baseURL (DefaultBaseURL when empty), authenticating with token. Repos
+// qualified with another host are sent to that host's APIBaseURL, with a
+// token from ResolveHostToken.
+func NewHTTPClient(baseURL, token string) *HTTPClient {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &HTTPClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		http:    &http.Client{},
+		cache:   newResponseCache(),
+		retry:   DefaultRetryPolicy,
+		sleep:   sleepContext,
+		jitter:  fullJitter,
+
+		resolveToken: ResolveHostToken,
+		tokens:       map[string]hostToken{},
+	}
+}
+
+// SetRetryPolicy replaces the policy used to retry idempotent requests.
+func (c *HTTPClient) SetRetryPolicy(p RetryPolicy) {
+	c.retry = p
+}
+
+// ResolveToken returns an API token from GH_TOKEN or GITHUB_TOKEN,
+// falling back to `gh auth token`.
+func ResolveToken() (string, error) {
+	for _, env := range []string{"GH_TOKEN", "GITHUB_TOKEN"} {
+		if token := os.Getenv(env); token != "" {
+			return token, nil
+		}
+	}
+	output, err := exec.Command("gh", "auth", "token").Output()
+	if err != nil {
+		return "", fmt.Errorf("gh auth token: %w", err)
+	}
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", fmt.Errorf("gh auth token: empty token")
+	}
+	return token, nil
+}
+
+// ListWorkflowRuns fetches a page of workflow runs for a repository,
+// following the Link header to find the next page. Runs of a host-qualified
+// repo carry the same qualified Repository.FullName.
+func (c *HTTPClient) ListWorkflowRuns(ctx context.Context, repo string, opts RunListOptions) (RunsPage, error) {
+	host, repo := SplitHost(repo)
+	return c.listRuns(ctx, host, fmt.Sprintf("repos/%s/actions/runs", repo), opts)
+}
+
+// ListWorkflowRunsForWorkflow fetches a page of the runs of one workflow,
+// given by file name (e.g. "ci.yaml"), which reaches runs too old to be on
+// the repository-wide pages.
+func (c *HTTPClient) ListWorkflowRunsForWorkflow(ctx context.Context, repo, workflowFile string, opts RunListOptions) (RunsPage, error) {
+	host, repo := SplitHost(repo)
+	return c.listRuns(ctx, host, fmt.Sprintf("repos/%s/actions/workflows/%s/runs", repo, workflowFile), opts)
+}
+
+// listRuns fetches a page of runs from a runs listing endpoint on host.
+func (c *HTTPClient) listRuns(ctx context.Context, host, path string, opts RunListOptions) (RunsPage, error) {
+	endpoint := path + "?" + opts.query()
+	value, err := c.cachedGet(ctx, host, endpoint, func(output []byte, header http.Header) (any, error) {
+		var response types.WorkflowRunsResponse
+		if err := json.Unmarshal(output, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		qualifyRuns(host, response.WorkflowRuns)
+		next, ok := nextPageFromLink(header.Get("Link"))
+		if !ok {
+			next = nextPageFromTotal(opts, response.TotalCount, len(response.WorkflowRuns))
+		}
+		return RunsPage{
+			Runs:       response.WorkflowRuns,
+			TotalCount: response.TotalCount,
+			NextPage:   next,
+		}, nil
+	})
+	if err != nil {
+		return RunsPage{}, err
+	}
+	return value.(RunsPage), nil
+}
+
+// nextPageFromLink extracts the page number of the rel="next" entry from an
+// RFC 8288 Link header. ok is false when the header is absent, in which case
+// the caller should fall back to total_count.
+func nextPageFromLink(link string) (page int, ok bool) {
+	if link == "" {
+		return 0, false
+	}
+	for _, part := range strings.Split(link, ",") {
+		target, params, found := strings.Cut(strings.TrimSpace(part), ";")
+		if !found || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+		u, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+		if err != nil {
+			continue
+		}
+		if n, err := strconv.Atoi(u.Query().Get("page")); err == nil {
+			return n, true
+		}
+	}
+	return 0, true
+}
+
+// GetJobs fetches jobs for a workflow run
+func (c *HTTPClient) GetJobs(ctx context.Context, repo string, runID int64) ([]types.Job, error) {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/jobs", repo, runID)
+	value, err := c.cachedGet(ctx, host, endpoint, func(output []byte, _ http.Header) (any, error) {
+		var response types.JobsResponse
+		if err := json.Unmarshal(output, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		return response.Jobs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]types.Job), nil
+}
+
+// GetJob fetches a single job, e.g. to poll its status
+func (c *HTTPClient) GetJob(ctx context.Context, repo string, jobID int64) (types.Job, error) {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/jobs/%d", repo, jobID)
+	value, err := c.cachedGet(ctx, host, endpoint, func(output []byte, _ http.Header) (any, error) {
+		var job types.Job
+		if err := json.Unmarshal(output, &job); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		return job, nil
+	})
+	if err != nil {
+		return types.Job{}, err
+	}
+	return value.(types.Job), nil
+}
+
+// GetJobLogs fetches logs for a specific job. The API answers with a redirect
+// to a short-lived download URL, which net/http follows without forwarding
+// the Authorization header.
+func (c *HTTPClient) GetJobLogs(ctx context.Context, repo string, jobID int64) (string, error) {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/jobs/%d/logs", repo, jobID)
+	output, _, err := c.apiCall(ctx, host, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+// ListArtifacts fetches the artifacts uploaded by a workflow run
+func (c *HTTPClient) ListArtifacts(ctx context.Context, repo string, runID int64) ([]types.Artifact, error) {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/artifacts?per_page=%d", repo, runID, MaxPerPage)
+	value, err := c.cachedGet(ctx, host, endpoint, func(output []byte, _ http.Header) (any, error) {
+		var response types.ArtifactsResponse
+		if err := json.Unmarshal(output, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		return response.Artifacts, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]types.Artifact), nil
+}
+
+// DownloadArtifact streams an artifact's zip archive into w. Like
+// GetJobLogs, the API redirects to a short-lived download URL.
+func (c *HTTPClient) DownloadArtifact(ctx context.Context, repo string, artifactID int64, w io.Writer) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/artifacts/%d/zip", repo, artifactID)
+	_, err := c.do(ctx, host, http.MethodGet, endpoint, nil, nil, w)
+	return err
+}
+
+// RerunWorkflow re-runs a workflow, optionally with debug logging enabled
+func (c *HTTPClient) RerunWorkflow(ctx context.Context, repo string, runID int64, debug bool) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/rerun", repo, runID)
+	var body any
+	if debug {
+		body = map[string]bool{"enable_debug_logging": true}
+	}
+	_, _, err := c.apiCall(ctx, host, http.MethodPost, endpoint, body)
+	return err
+}
+
+// RerunFailedJobs re-runs only failed jobs in a workflow and the jobs that
+// depend on them, optionally with debug logging enabled
+func (c *HTTPClient) RerunFailedJobs(ctx context.Context, repo string, runID int64, debug bool) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/rerun-failed-jobs", repo, runID)
+	var body any
+	if debug {
+		body = map[string]bool{"enable_debug_logging": true}
+	}
+	_, _, err := c.apiCall(ctx, host, http.MethodPost, endpoint, body)
+	return err
+}
+
+// RerunJob re-runs a single job and the jobs that depend on it, optionally
+// with debug logging enabled
+func (c *HTTPClient) RerunJob(ctx context.Context, repo string, jobID int64, debug bool) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/jobs/%d/rerun", repo, jobID)
+	var body any
+	if debug {
+		body = map[string]bool{"enable_debug_logging": true}
+	}
+	_, _, err := c.apiCall(ctx, host, http.MethodPost, endpoint, body)
+	return err
+}
+
+// CancelWorkflow cancels a running workflow
+func (c *HTTPClient) CancelWorkflow(ctx context.Context, repo string, runID int64) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/cancel", repo, runID)
+	_, _, err := c.apiCall(ctx, host, http.MethodPost, endpoint, nil)
+	return err
+}
+
+// DeleteRun deletes a completed workflow run along with its logs and
+// artifacts
+func (c *HTTPClient) DeleteRun(ctx context.Context, repo string, runID int64) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d", repo, runID)
+	_, _, err := c.apiCall(ctx, host, http.MethodDelete, endpoint, nil)
+	return err
+}
+
+// DeleteRunLogs deletes all logs of a workflow run, keeping the run itself
+func (c *HTTPClient) DeleteRunLogs(ctx context.Context, repo string, runID int64) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/logs", repo, runID)
+	_, _, err := c.apiCall(ctx, host, http.MethodDelete, endpoint, nil)
+	return err
+}
+
+// ApproveRun lets a workflow run from a fork pull request proceed
+func (c *HTTPClient) ApproveRun(ctx context.Context, repo string, runID int64) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/approve", repo, runID)
+	_, _, err := c.apiCall(ctx, host, http.MethodPost, endpoint, nil)
+	return err
+}
+
+// ListPendingDeployments fetches the environments a waiting run needs
+// approval to deploy to
+func (c *HTTPClient) ListPendingDeployments(ctx context.Context, repo string, runID int64) ([]types.PendingDeployment, error) {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/pending_deployments", repo, runID)
+	output, _, err := c.apiCall(ctx, host, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	var deployments []types.PendingDeployment
+	if err := json.Unmarshal(output, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return deployments, nil
+}
+
+// ReviewPendingDeployments approves or rejects a waiting run's deployments
+// to the given environments
+func (c *HTTPClient) ReviewPendingDeployments(ctx context.Context, repo string, runID int64, environmentIDs []int64, approve bool, comment string) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%d/pending_deployments", repo, runID)
+	body := struct {
+		EnvironmentIDs []int64 `json:"environment_ids"`
+		State          string  `json:"state"`
+		Comment        string  `json:"comment"`
+	}{EnvironmentIDs: environmentIDs, State: reviewState(approve), Comment: comment}
+	_, _, err := c.apiCall(ctx, host, http.MethodPost, endpoint, body)
+	return err
+}
+
+// DispatchWorkflow triggers a workflow_dispatch event on the given ref.
+// workflowFile is the filename, e.g. "ci.yaml"; inputs are the
+// workflow_dispatch input values, sent as strings.
+func (c *HTTPClient) DispatchWorkflow(ctx context.Context, repo, workflowFile, ref string, inputs map[string]string) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/workflows/%s/dispatches", repo, workflowFile)
+	body := struct {
+		Ref    string            `json:"ref"`
+		Inputs map[string]string `json:"inputs,omitempty"`
+	}{Ref: ref, Inputs: inputs}
+	_, _, err := c.apiCall(ctx, host, http.MethodPost, endpoint, body)
+	return err
+}
+
+// ListWorkflows fetches the workflows registered in a repository, with
+// their enabled/disabled state
+func (c *HTTPClient) ListWorkflows(ctx context.Context, repo string) ([]types.Workflow, error) {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/workflows?per_page=%d", repo, MaxPerPage)
+	value, err := c.cachedGet(ctx, host, endpoint, func(output []byte, _ http.Header) (any, error) {
+		var response types.WorkflowsResponse
+		if err := json.Unmarshal(output, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		return response.Workflows, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]types.Workflow), nil
+}
+
+// EnableWorkflow lets a disabled workflow's triggers start runs again
+func (c *HTTPClient) EnableWorkflow(ctx context.Context, repo string, workflowID int64) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/workflows/%d/enable", repo, workflowID)
+	_, _, err := c.apiCall(ctx, host, http.MethodPut, endpoint, nil)
+	return err
+}
+
+// DisableWorkflow stops a workflow's triggers from starting runs
+func (c *HTTPClient) DisableWorkflow(ctx context.Context, repo string, workflowID int64) error {
+	host, repo := SplitHost(repo)
+	endpoint := fmt.Sprintf("repos/%s/actions/workflows/%d/disable", repo, workflowID)
+	_, _, err := c.apiCall(ctx, host, http.MethodPut, endpoint, nil)
+	return err
+}
+
+// OpenInBrowser opens a URL in the default browser
+func (c *HTTPClient) OpenInBrowser(url string) error {
+	return openBrowser(url)
+}
+
+// RateLimit returns the quota reported by the most recent API response.
+func (c *HTTPClient) RateLimit() RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rate
+}
+
+// cachedGet performs a conditional GET of endpoint on host. When a previous response
+// carried an ETag or Last-Modified validator it is sent back, and a
+// 304 Not Modified is answered by decoding the previous body again;
+// otherwise decode turns the fresh body into the value, and the body is
+// cached.
+func (c *HTTPClient) cachedGet(ctx context.Context, host, endpoint string, decode func(body []byte, header http.Header) (any, error)) (any, error) {
+	header := http.Header{}
+	key := qualifyRepo(host, endpoint) // endpoints are only unique per host
+	entry, cached := c.cache.get(key)
+	if cached {
+		if entry.etag != "" {
+			header.Set("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" {
+			header.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+
+	resp, err := c.do(ctx, host, http.MethodGet, endpoint, nil, header, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cached && resp.status == http.StatusNotModified {
+		c.cache.record(key, true)
+		return decode(entry.body, entry.header)
+	}
+	c.cache.record(key, false)
+
+	value, err := decode(resp.body, resp.header)
+	if err != nil {
+		return nil, err
+	}
+	etag, lastModified := resp.header.Get("ETag"), resp.header.Get("Last-Modified")
+	if etag != "" || lastModified != "" {
+		c.cache.put(key, cacheEntry{etag: etag, lastModified: lastModified, body: resp.body, header: resp.header})
+	}
+	return value, nil
+}
+
+// tokenRetryInterval is how long a failed token lookup is remembered before
+// the host's token is resolved again, e.g. after `gh auth login`.
+const tokenRetryInterval = time.Minute
+
+// hostToken is the result of resolving a host's token.
+type hostToken struct {
+	token    string
+	err      error
+	resolved time.Time
+}
+
+// hostAuth returns the API root and token for host, resolving and caching
+// tokens for GitHub Enterprise Server hosts on first use.
+func (c *HTTPClient) hostAuth(host string) (baseURL, token string, err error) {
+	if host == DefaultHost {
+		return c.baseURL, c.token, nil
+	}
+	c.tokensMu.Lock()
+	defer c.tokensMu.Unlock()
+	t, ok := c.tokens[host]
+	if !ok || (t.err != nil && time.Since(t.resolved) >= tokenRetryInterval) {
+		t = hostToken{resolved: time.Now()}
+		t.token, t.err = c.resolveToken(host)
+		c.tokens[host] = t
+	}
+	if t.err != nil {
+		return "", "", fmt.Errorf("resolve token for %s (set GH_ENTERPRISE_TOKEN or run `gh auth login --hostname %s`): %w", host, host, t.err)
+	}
+	return APIBaseURL(host), t.token, nil
+}
+
+// apiResponse is a fully read API response; body is empty when it was
+// streamed to a sink.
+type apiResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+// apiCall performs a REST request against endpoint (relative to host's API
+// root), JSON-encoding body when non-nil, and returns the response body and
+// headers.
+func (c *HTTPClient) apiCall(ctx context.Context, host, method, endpoint string, body any) ([]byte, http.Header, error) {
+	resp, err := c.do(ctx, host, method, endpoint, body, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.body, resp.header, nil
+}
+
+// do sends a request with the standard API headers plus any in header, and
+// returns an *APIError for 4xx/5xx responses. Idempotent requests that fail
+// transiently are retried according to the client's RetryPolicy, honouring
+// Retry-After. A successful response body is copied to sink when it is
+// non-nil; once any of it has been written the request is not retried.
+func (c *HTTPClient) do(ctx context.Context, host, method, endpoint string, body any, header http.Header, sink io.Writer) (*apiResponse, error) {
+	var written countingWriter
+	if sink != nil {
+		written.w = sink
+		sink = &written
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, host, method, endpoint, body, header, sink)
+		if err == nil || attempt >= c.retry.MaxAttempts || !idempotent(method) || !retryable(ctx, err) || written.n > 0 {
+			return resp, err
+		}
+
+		delay := c.retry.backoff(attempt, c.jitter)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			if apiErr.RetryAfter > c.retry.MaxDelay {
+				return nil, err
+			}
+			delay = apiErr.RetryAfter
+		}
+		slog.Debug("retrying request", "method", method, "endpoint", endpoint,
+			"attempt", attempt, "delay", delay, "err", err)
+		if err := c.sleep(ctx, delay); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", method, endpoint, err)
+		}
+	}
+}
+
+// send performs a single attempt of a request.
+func (c *HTTPClient) send(ctx context.Context, host, method, endpoint string, body any, header http.Header, sink io.Writer) (*apiResponse, error) {
+	baseURL, token, err := c.hostAuth(host)
+	if err != nil {
+		return nil, err
+	}
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("encode request body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+"/"+endpoint, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	rl, hasRate := parseRateLimit(resp.Header)
+	if hasRate {
+		c.mu.Lock()
+		c.rate = rl
+		c.mu.Unlock()
+	}
+
+	if sink != nil && resp.StatusCode < http.StatusBadRequest {
+		if _, err := io.Copy(sink, resp.Body); err != nil {
+			return nil, fmt.Errorf("read response: %w", err)
+		}
+		return &apiResponse{status: resp.StatusCode, header: resp.Header}, nil
+	}
+	output, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := newAPIError(method, endpoint, resp.StatusCode, output)
+		apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if hasRate && rl.Remaining == 0 &&
+			(resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) {
+			apiErr.RateLimited = true
+		}
+		return nil, apiErr
+	}
+	return &apiResponse{status: resp.StatusCode, header: resp.Header, body: output}, nil
+}
+
+// countingWriter counts the bytes written through it to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
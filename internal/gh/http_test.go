@@ -0,0 +1,514 @@
+package gh
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPClientListWorkflowRuns(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer tok")
+		}
+		if r.URL.Path != "/repos/owner/repo/actions/runs" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("per_page"); got != "5" {
+			t.Errorf("per_page = %q, want 5", got)
+		}
+		io.WriteString(w, `{"total_count":1,"workflow_runs":[{"id":42,"name":"ci","status":"completed"}]}`)
+	}))
+	defer srv.Close()
+
+	page, err := NewHTTPClient(srv.URL, "tok").ListWorkflowRuns(context.Background(), "owner/repo", RunListOptions{PerPage: 5})
+	if err != nil {
+		t.Fatalf("ListWorkflowRuns error: %v", err)
+	}
+	if len(page.Runs) != 1 || page.Runs[0].ID != 42 || page.Runs[0].Name != "ci" {
+		t.Errorf("runs = %+v", page.Runs)
+	}
+	if page.NextPage != 0 {
+		t.Errorf("NextPage = %d, want 0", page.NextPage)
+	}
+}
+
+func TestHTTPClientListWorkflowRunsPaging(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("page"); got != "2" {
+			t.Errorf("page = %q, want 2", got)
+		}
+		w.Header().Set("Link", `<https://api.github.com/repositories/1/actions/runs?per_page=1&page=3>; rel="next", `+
+			`<https://api.github.com/repositories/1/actions/runs?per_page=1&page=9>; rel="last"`)
+		io.WriteString(w, `{"total_count":9,"workflow_runs":[{"id":2}]}`)
+	}))
+	defer srv.Close()
+
+	page, err := NewHTTPClient(srv.URL, "tok").ListWorkflowRuns(context.Background(), "owner/repo", RunListOptions{PerPage: 1, Page: 2})
+	if err != nil {
+		t.Fatalf("ListWorkflowRuns error: %v", err)
+	}
+	if page.NextPage != 3 || page.TotalCount != 9 {
+		t.Errorf("page = %+v, want NextPage=3 TotalCount=9", page)
+	}
+}
+
+func TestNextPageFromLink(t *testing.T) {
+	tests := []struct {
+		link     string
+		wantPage int
+		wantOK   bool
+	}{
+		{"", 0, false},
+		{`<https://x/runs?page=2>; rel="next", <https://x/runs?page=5>; rel="last"`, 2, true},
+		{`<https://x/runs?page=4>; rel="prev", <https://x/runs?page=1>; rel="first"`, 0, true},
+	}
+	for _, tt := range tests {
+		page, ok := nextPageFromLink(tt.link)
+		if page != tt.wantPage || ok != tt.wantOK {
+			t.Errorf("nextPageFromLink(%q) = (%d, %v), want (%d, %v)", tt.link, page, ok, tt.wantPage, tt.wantOK)
+		}
+	}
+}
+
+func TestHTTPClientRerunWorkflowDebug(t *testing.T) {
+	var body map[string]bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/owner/repo/actions/runs/7/rerun" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	if err := NewHTTPClient(srv.URL, "tok").RerunWorkflow(context.Background(), "owner/repo", 7, true); err != nil {
+		t.Fatalf("RerunWorkflow error: %v", err)
+	}
+	if !body["enable_debug_logging"] {
+		t.Errorf("body = %v, want enable_debug_logging=true", body)
+	}
+}
+
+func TestHTTPClientRerunJob(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/owner/repo/actions/jobs/9/rerun" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if r.ContentLength > 0 {
+			t.Errorf("unexpected body for a non-debug re-run")
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	if err := NewHTTPClient(srv.URL, "tok").RerunJob(context.Background(), "owner/repo", 9, false); err != nil {
+		t.Fatalf("RerunJob error: %v", err)
+	}
+}
+
+func TestHTTPClientDispatchNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"message":"Not Found"}`)
+	}))
+	defer srv.Close()
+
+	err := NewHTTPClient(srv.URL, "tok").DispatchWorkflow(context.Background(), "owner/repo", "ci.yaml", "main", nil)
+	if !IsNotFound(err) {
+		t.Fatalf("DispatchWorkflow error = %v, want not found", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Endpoint != "repos/owner/repo/actions/workflows/ci.yaml/dispatches" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+}
+
+func TestHTTPClientRateLimitedError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"message":"API rate limit exceeded for user ID 1.","documentation_url":"https://docs.github.com/rest"}`)
+	}))
+	defer srv.Close()
+
+	_, err := NewHTTPClient(srv.URL, "tok").GetJobs(context.Background(), "owner/repo", 1)
+	if !IsRateLimited(err) {
+		t.Errorf("IsRateLimited(%v) = false, want true", err)
+	}
+	if IsForbidden(err) {
+		t.Errorf("IsForbidden(%v) = true, want false for rate limiting", err)
+	}
+}
+
+func TestHTTPClientGetJob(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/actions/jobs/42" {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"Not Found"}`)
+			return
+		}
+		io.WriteString(w, `{"id":42,"run_id":7,"name":"build","status":"in_progress"}`)
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL, "tok")
+	job, err := c.GetJob(context.Background(), "owner/repo", 42)
+	if err != nil {
+		t.Fatalf("GetJob error: %v", err)
+	}
+	if job.ID != 42 || job.Status != "in_progress" {
+		t.Errorf("job = %+v, want job 42 in progress", job)
+	}
+	if _, err := c.GetJob(context.Background(), "owner/repo", 43); !IsNotFound(err) {
+		t.Errorf("GetJob of a missing job error = %v, want not found", err)
+	}
+}
+
+func TestResolveTokenFromEnv(t *testing.T) {
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "from-github-token")
+	got, err := ResolveToken()
+	if err != nil {
+		t.Fatalf("ResolveToken error: %v", err)
+	}
+	if got != "from-github-token" {
+		t.Errorf("ResolveToken() = %q, want %q", got, "from-github-token")
+	}
+
+	t.Setenv("GH_TOKEN", "from-gh-token")
+	if got, _ := ResolveToken(); got != "from-gh-token" {
+		t.Errorf("ResolveToken() = %q, want GH_TOKEN to take precedence", got)
+	}
+}
+
+func TestHTTPClientETagCache(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `W/"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `W/"v1"`)
+		io.WriteString(w, `{"total_count":2,"jobs":[{"id":1,"name":"build"},{"id":2,"name":"test"}]}`)
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL, "tok")
+	first, err := c.GetJobs(context.Background(), "owner/repo", 7)
+	if err != nil {
+		t.Fatalf("first GetJobs error: %v", err)
+	}
+	second, err := c.GetJobs(context.Background(), "owner/repo", 7)
+	if err != nil {
+		t.Fatalf("second GetJobs error: %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+	if len(second) != 2 || second[1].Name != first[1].Name {
+		t.Errorf("cached jobs = %+v, want %+v", second, first)
+	}
+	if hits, misses := c.cache.stats(); hits != 1 || misses != 1 {
+		t.Errorf("cache stats = (%d hits, %d misses), want (1, 1)", hits, misses)
+	}
+
+	// each hit is a value of its own, so callers may modify it
+	second[0].Name = "changed"
+	third, err := c.GetJobs(context.Background(), "owner/repo", 7)
+	if err != nil {
+		t.Fatalf("third GetJobs error: %v", err)
+	}
+	if third[0].Name != "build" {
+		t.Errorf("cached job name = %q, want %q", third[0].Name, "build")
+	}
+}
+
+func TestHTTPClientRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4321")
+		w.Header().Set("X-RateLimit-Used", "679")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		io.WriteString(w, `{"total_count":0,"jobs":[]}`)
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL, "tok")
+	if c.RateLimit().Known() {
+		t.Fatal("RateLimit known before any request")
+	}
+	if _, err := c.GetJobs(context.Background(), "owner/repo", 1); err != nil {
+		t.Fatalf("GetJobs error: %v", err)
+	}
+	rl := c.RateLimit()
+	if rl.Limit != 5000 || rl.Remaining != 4321 || rl.Used != 679 || rl.Reset.Unix() != 1700000000 {
+		t.Errorf("RateLimit() = %+v", rl)
+	}
+}
+
+func TestHTTPClientDispatchInputs(t *testing.T) {
+	var body struct {
+		Ref    string            `json:"ref"`
+		Inputs map[string]string `json:"inputs"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	inputs := map[string]string{"environment": "staging", "dry_run": "true"}
+	if err := NewHTTPClient(srv.URL, "tok").DispatchWorkflow(context.Background(), "owner/repo", "deploy.yaml", "main", inputs); err != nil {
+		t.Fatalf("DispatchWorkflow error: %v", err)
+	}
+	if body.Ref != "main" || body.Inputs["environment"] != "staging" || body.Inputs["dry_run"] != "true" {
+		t.Errorf("body = %+v", body)
+	}
+}
+
+func TestHTTPClientEnterpriseHost(t *testing.T) {
+	var gotURL, gotAuth string
+	c := NewHTTPClient("", "dotcom-tok")
+	c.http = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		gotURL, gotAuth = r.URL.String(), r.Header.Get("Authorization")
+		return fakeResponse(http.StatusOK, nil,
+			`{"total_count":1,"workflow_runs":[{"id":7,"repository":{"full_name":"owner/repo"}}]}`), nil
+	})}
+	resolved := 0
+	c.resolveToken = func(host string) (string, error) {
+		resolved++
+		if host != "ghe.example.com" {
+			t.Errorf("resolveToken(%q)", host)
+		}
+		return "ghe-tok", nil
+	}
+
+	for range 2 {
+		page, err := c.ListWorkflowRuns(context.Background(), "ghe.example.com/owner/repo", RunListOptions{PerPage: 1})
+		if err != nil {
+			t.Fatalf("ListWorkflowRuns error: %v", err)
+		}
+		if got := page.Runs[0].Repository.FullName; got != "ghe.example.com/owner/repo" {
+			t.Errorf("FullName = %q, want host-qualified", got)
+		}
+	}
+	if want := "https://ghe.example.com/api/v3/repos/owner/repo/actions/runs?per_page=1"; gotURL != want {
+		t.Errorf("URL = %q, want %q", gotURL, want)
+	}
+	if gotAuth != "Bearer ghe-tok" {
+		t.Errorf("Authorization = %q, want the enterprise token", gotAuth)
+	}
+	if resolved != 1 {
+		t.Errorf("token resolved %d times, want 1", resolved)
+	}
+}
+
+func TestHTTPClientTokenLookup(t *testing.T) {
+	c := NewHTTPClient("", "dotcom-tok")
+	release := make(chan struct{})
+	lookups := 0
+	c.resolveToken = func(string) (string, error) {
+		lookups++
+		<-release
+		return "", errors.New("not logged in")
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := c.GetJobs(context.Background(), "ghe.example.com/owner/repo", 1)
+		done <- err
+	}()
+	// RateLimit must not wait for the gh process resolving the token
+	rl := make(chan struct{})
+	go func() { c.RateLimit(); close(rl) }()
+	select {
+	case <-rl:
+	case <-time.After(5 * time.Second):
+		t.Fatal("RateLimit blocked on a token lookup")
+	}
+	close(release)
+	if err := <-done; err == nil {
+		t.Fatal("GetJobs succeeded, want the token error")
+	}
+
+	// the failure is remembered rather than looked up on every request
+	if _, err := c.GetJobs(context.Background(), "ghe.example.com/owner/repo", 1); err == nil {
+		t.Fatal("GetJobs succeeded, want the token error")
+	}
+	if lookups != 1 {
+		t.Errorf("token looked up %d times, want 1", lookups)
+	}
+}
+
+func TestHTTPClientArtifacts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/actions/runs/7/artifacts":
+			io.WriteString(w, `{"total_count":1,"artifacts":[{"id":3,"name":"report","size_in_bytes":2048,"expired":false}]}`)
+		case "/repos/owner/repo/actions/artifacts/3/zip":
+			http.Redirect(w, r, "/blob/report.zip", http.StatusFound)
+		case "/blob/report.zip":
+			if r.Header.Get("Authorization") == "" {
+				t.Errorf("same-host redirect dropped Authorization")
+			}
+			io.WriteString(w, "PK-zip-bytes")
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL, "tok")
+	artifacts, err := c.ListArtifacts(context.Background(), "owner/repo", 7)
+	if err != nil {
+		t.Fatalf("ListArtifacts error: %v", err)
+	}
+	if len(artifacts) != 1 || artifacts[0].ID != 3 || artifacts[0].SizeInBytes != 2048 {
+		t.Errorf("artifacts = %+v", artifacts)
+	}
+	var data bytes.Buffer
+	if err := c.DownloadArtifact(context.Background(), "owner/repo", 3, &data); err != nil {
+		t.Fatalf("DownloadArtifact error: %v", err)
+	}
+	if data.String() != "PK-zip-bytes" {
+		t.Errorf("data = %q", data.String())
+	}
+}
+
+func TestHTTPClientReviewPendingDeployments(t *testing.T) {
+	var body struct {
+		EnvironmentIDs []int64 `json:"environment_ids"`
+		State          string  `json:"state"`
+		Comment        string  `json:"comment"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/actions/runs/7/pending_deployments" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if r.Method == http.MethodGet {
+			io.WriteString(w, `[{"environment":{"id":11,"name":"production"},"current_user_can_approve":true,`+
+				`"reviewers":[{"type":"User","reviewer":{"login":"alice"}},{"type":"Team","reviewer":{"slug":"ops"}}]}]`)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		io.WriteString(w, `[]`)
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL, "tok")
+	deployments, err := c.ListPendingDeployments(context.Background(), "owner/repo", 7)
+	if err != nil {
+		t.Fatalf("ListPendingDeployments error: %v", err)
+	}
+	if len(deployments) != 1 || deployments[0].Environment.Name != "production" ||
+		len(deployments[0].Reviewers) != 2 || deployments[0].Reviewers[1].Name() != "@ops" {
+		t.Errorf("deployments = %+v", deployments)
+	}
+
+	if err := c.ReviewPendingDeployments(context.Background(), "owner/repo", 7, []int64{11}, false, "not today"); err != nil {
+		t.Fatalf("ReviewPendingDeployments error: %v", err)
+	}
+	if body.State != "rejected" || body.Comment != "not today" || len(body.EnvironmentIDs) != 1 || body.EnvironmentIDs[0] != 11 {
+		t.Errorf("body = %+v", body)
+	}
+}
+
+func TestHTTPClientWorkflowState(t *testing.T) {
+	var toggled []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo/actions/workflows":
+			io.WriteString(w, `{"total_count":2,"workflows":[`+
+				`{"id":1,"name":"ci","path":".github/workflows/ci.yaml","state":"active"},`+
+				`{"id":2,"name":"nightly","path":".github/workflows/nightly.yaml","state":"disabled_inactivity"}]}`)
+		case r.Method == http.MethodPut:
+			toggled = append(toggled, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL, "tok")
+	workflows, err := c.ListWorkflows(context.Background(), "owner/repo")
+	if err != nil {
+		t.Fatalf("ListWorkflows error: %v", err)
+	}
+	if len(workflows) != 2 || workflows[0].Disabled() || !workflows[1].Disabled() {
+		t.Errorf("workflows = %+v", workflows)
+	}
+
+	if err := c.DisableWorkflow(context.Background(), "owner/repo", 1); err != nil {
+		t.Fatalf("DisableWorkflow error: %v", err)
+	}
+	if err := c.EnableWorkflow(context.Background(), "owner/repo", 2); err != nil {
+		t.Fatalf("EnableWorkflow error: %v", err)
+	}
+	want := []string{"/repos/owner/repo/actions/workflows/1/disable", "/repos/owner/repo/actions/workflows/2/enable"}
+	if len(toggled) != 2 || toggled[0] != want[0] || toggled[1] != want[1] {
+		t.Errorf("toggled = %v, want %v", toggled, want)
+	}
+}
+
+func TestHTTPClientDeleteRun(t *testing.T) {
+	var deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("method = %s, want DELETE", r.Method)
+		}
+		deleted = append(deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL, "tok")
+	if err := c.DeleteRunLogs(context.Background(), "owner/repo", 7); err != nil {
+		t.Fatalf("DeleteRunLogs error: %v", err)
+	}
+	if err := c.DeleteRun(context.Background(), "owner/repo", 7); err != nil {
+		t.Fatalf("DeleteRun error: %v", err)
+	}
+	want := []string{"/repos/owner/repo/actions/runs/7/logs", "/repos/owner/repo/actions/runs/7"}
+	if len(deleted) != 2 || deleted[0] != want[0] || deleted[1] != want[1] {
+		t.Errorf("deleted = %v, want %v", deleted, want)
+	}
+}
+
+func TestHTTPClientListWorkflowRunsForWorkflow(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/actions/workflows/release.yaml/runs" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("branch"); got != "main" {
+			t.Errorf("branch = %q, want main", got)
+		}
+		io.WriteString(w, `{"total_count":1,"workflow_runs":[{"id":5,"workflow_id":30,"name":"release"}]}`)
+	}))
+	defer srv.Close()
+
+	page, err := NewHTTPClient(srv.URL, "tok").ListWorkflowRunsForWorkflow(context.Background(), "owner/repo", "release.yaml",
+		RunListOptions{PerPage: 10, Filter: RunFilter{Branch: "main"}})
+	if err != nil {
+		t.Fatalf("ListWorkflowRunsForWorkflow error: %v", err)
+	}
+	if len(page.Runs) != 1 || page.Runs[0].WorkflowID != 30 || page.NextPage != 0 {
+		t.Errorf("page = %+v", page)
+	}
+}
@@ -0,0 +1,42 @@
+package gh
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit is the REST API quota as last reported by the X-RateLimit-*
+// response headers.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Used      int
+	Reset     time.Time
+}
+
+// Known reports whether any rate-limit headers have been observed.
+func (r RateLimit) Known() bool {
+	return r.Limit > 0
+}
+
+// Exhausted reports whether the quota is used up and has not yet reset.
+func (r RateLimit) Exhausted(now time.Time) bool {
+	return r.Known() && r.Remaining <= 0 && now.Before(r.Reset)
+}
+
+// parseRateLimit reads the X-RateLimit-* headers; ok is false when they are
+// absent (e.g. on redirected log downloads).
+func parseRateLimit(h http.Header) (rl RateLimit, ok bool) {
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	rl.Limit = limit
+	rl.Remaining, _ = strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	rl.Used, _ = strconv.Atoi(h.Get("X-RateLimit-Used"))
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+	return rl, true
+}
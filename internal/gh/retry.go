@@ -0,0 +1,102 @@
+package gh
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how idempotent requests are retried after transient
+// failures: network errors, 5xx responses and secondary rate limits.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first; <= 1 disables retries
+	BaseDelay   time.Duration // delay before the first retry, doubled for each one after
+	MaxDelay    time.Duration // cap on any single delay; a longer Retry-After is not waited out
+}
+
+// DefaultRetryPolicy is used by NewHTTPClient.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// backoff returns the delay before retry number attempt (1-based): exponential
+// in attempt, capped at MaxDelay, with jitter applied.
+func (p RetryPolicy) backoff(attempt int, jitter func(time.Duration) time.Duration) time.Duration {
+	d := p.BaseDelay << (attempt - 1)
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return jitter(d)
+}
+
+// fullJitter picks a delay uniformly from [d/2, d] so clients that failed
+// together do not retry in lockstep.
+func fullJitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int64N(int64(d-half)+1))
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// idempotent reports whether requests with method may be safely repeated.
+func idempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// retryable reports whether a failed attempt is worth repeating.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		// only network failures (connection reset, timeout, DNS...) are
+		// transient; a missing token or a bad request will fail again
+		var netErr net.Error
+		return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET)
+	}
+	if apiErr.StatusCode >= http.StatusInternalServerError {
+		return true
+	}
+	// Primary rate limits reset on the hour; only secondary limits, which
+	// ask the client to back off briefly, are worth retrying.
+	return apiErr.RateLimited &&
+		(apiErr.RetryAfter > 0 || strings.Contains(strings.ToLower(apiErr.Message), "secondary rate limit"))
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an
+// HTTP date; it returns 0 when the header is absent or malformed.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,233 @@
+package gh
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// roundTripFunc is a fake http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func fakeResponse(status int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// newFakeClient returns an HTTPClient whose requests are answered by
+// responses in order, recording the delays it would have slept.
+func newFakeClient(t *testing.T, responses ...func() (*http.Response, error)) (*HTTPClient, *[]time.Duration, *int) {
+	t.Helper()
+	var sleeps []time.Duration
+	calls := 0
+	c := NewHTTPClient("https://api.example.test", "tok")
+	c.http = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if calls >= len(responses) {
+			t.Fatalf("unexpected request #%d: %s %s", calls+1, r.Method, r.URL)
+		}
+		calls++
+		return responses[calls-1]()
+	})}
+	c.jitter = func(d time.Duration) time.Duration { return d }
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	return c, &sleeps, &calls
+}
+
+func respond(status int, header http.Header, body string) func() (*http.Response, error) {
+	return func() (*http.Response, error) { return fakeResponse(status, header, body), nil }
+}
+
+const emptyJobs = `{"total_count":0,"jobs":[]}`
+
+func TestRetryServerErrorsWithBackoff(t *testing.T) {
+	c, sleeps, calls := newFakeClient(t,
+		respond(http.StatusBadGateway, nil, "bad gateway"),
+		respond(http.StatusServiceUnavailable, nil, ""),
+		respond(http.StatusOK, nil, emptyJobs),
+	)
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second})
+
+	if _, err := c.GetJobs(context.Background(), "owner/repo", 1); err != nil {
+		t.Fatalf("GetJobs error: %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("calls = %d, want 3", *calls)
+	}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}
+	if len(*sleeps) != 2 || (*sleeps)[0] != want[0] || (*sleeps)[1] != want[1] {
+		t.Errorf("sleeps = %v, want %v", *sleeps, want)
+	}
+}
+
+func TestRetryNetworkError(t *testing.T) {
+	c, _, calls := newFakeClient(t,
+		func() (*http.Response, error) { return nil, errors.New("connection reset by peer") },
+		respond(http.StatusOK, nil, emptyJobs),
+	)
+	if _, err := c.GetJobs(context.Background(), "owner/repo", 1); err != nil {
+		t.Fatalf("GetJobs error: %v", err)
+	}
+	if *calls != 2 {
+		t.Errorf("calls = %d, want 2", *calls)
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	c, sleeps, _ := newFakeClient(t,
+		respond(http.StatusForbidden, http.Header{"Retry-After": {"7"}},
+			`{"message":"You have exceeded a secondary rate limit."}`),
+		respond(http.StatusOK, nil, emptyJobs),
+	)
+	if _, err := c.GetJobs(context.Background(), "owner/repo", 1); err != nil {
+		t.Fatalf("GetJobs error: %v", err)
+	}
+	if len(*sleeps) != 1 || (*sleeps)[0] != 7*time.Second {
+		t.Errorf("sleeps = %v, want [7s]", *sleeps)
+	}
+}
+
+func TestNoRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(c *HTTPClient) error
+		resp func() (*http.Response, error)
+	}{
+		{
+			name: "non-idempotent POST",
+			call: func(c *HTTPClient) error { return c.CancelWorkflow(context.Background(), "owner/repo", 1) },
+			resp: respond(http.StatusBadGateway, nil, ""),
+		},
+		{
+			name: "client error",
+			call: func(c *HTTPClient) error { _, err := c.GetJobs(context.Background(), "owner/repo", 1); return err },
+			resp: respond(http.StatusNotFound, nil, `{"message":"Not Found"}`),
+		},
+		{
+			name: "primary rate limit",
+			call: func(c *HTTPClient) error { _, err := c.GetJobs(context.Background(), "owner/repo", 1); return err },
+			resp: respond(http.StatusForbidden,
+				http.Header{"X-Ratelimit-Limit": {"5000"}, "X-Ratelimit-Remaining": {"0"}},
+				`{"message":"API rate limit exceeded"}`),
+		},
+		{
+			name: "retry-after beyond max delay",
+			call: func(c *HTTPClient) error { _, err := c.GetJobs(context.Background(), "owner/repo", 1); return err },
+			resp: respond(http.StatusTooManyRequests, http.Header{"Retry-After": {"3600"}}, ""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, sleeps, calls := newFakeClient(t, tt.resp)
+			if err := tt.call(c); err == nil {
+				t.Fatal("error = nil, want failure")
+			}
+			if *calls != 1 || len(*sleeps) != 0 {
+				t.Errorf("calls = %d, sleeps = %v; want a single attempt", *calls, *sleeps)
+			}
+		})
+	}
+}
+
+func TestNoRetryOnTokenError(t *testing.T) {
+	c, sleeps, calls := newFakeClient(t)
+	lookups := 0
+	c.resolveToken = func(string) (string, error) {
+		lookups++
+		return "", errors.New("not logged in to ghe.example.com")
+	}
+	if _, err := c.GetJobs(context.Background(), "ghe.example.com/owner/repo", 1); err == nil {
+		t.Fatal("error = nil, want the token error")
+	}
+	if lookups != 1 || *calls != 0 || len(*sleeps) != 0 {
+		t.Errorf("lookups = %d, calls = %d, sleeps = %v; want a single failed lookup", lookups, *calls, *sleeps)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	c, _, calls := newFakeClient(t,
+		respond(http.StatusInternalServerError, nil, ""),
+		respond(http.StatusInternalServerError, nil, ""),
+	)
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Second})
+	_, err := c.GetJobs(context.Background(), "owner/repo", 1)
+	if statusOf(err) != http.StatusInternalServerError {
+		t.Errorf("error = %v, want HTTP 500", err)
+	}
+	if *calls != 2 {
+		t.Errorf("calls = %d, want 2", *calls)
+	}
+}
+
+func TestBackoffCapsAtMaxDelay(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	identity := func(d time.Duration) time.Duration { return d }
+	if got := p.backoff(1, identity); got != time.Second {
+		t.Errorf("backoff(1) = %v, want 1s", got)
+	}
+	if got := p.backoff(4, identity); got != 5*time.Second {
+		t.Errorf("backoff(4) = %v, want 5s (capped)", got)
+	}
+	for range 100 {
+		if got := fullJitter(time.Second); got < 500*time.Millisecond || got > time.Second {
+			t.Fatalf("fullJitter(1s) = %v, want within [500ms, 1s]", got)
+		}
+	}
+}
+
+// failingReader yields data, then fails as a dropped connection would.
+type failingReader struct{ data string }
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, errors.New("connection reset by peer")
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestRetryStreamedDownload(t *testing.T) {
+	c, _, calls := newFakeClient(t,
+		respond(http.StatusBadGateway, nil, "bad gateway"),
+		respond(http.StatusOK, nil, "PK-zip-bytes"),
+	)
+	var out strings.Builder
+	if err := c.DownloadArtifact(context.Background(), "owner/repo", 3, &out); err != nil {
+		t.Fatalf("DownloadArtifact error: %v", err)
+	}
+	if *calls != 2 || out.String() != "PK-zip-bytes" {
+		t.Errorf("calls = %d, out = %q; want the error body kept out of the download", *calls, out.String())
+	}
+
+	// a body that fails part way has already been written, so is not retried
+	c, _, calls = newFakeClient(t,
+		func() (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{},
+				Body: io.NopCloser(&failingReader{data: "PK-zi"})}, nil
+		},
+	)
+	out.Reset()
+	if err := c.DownloadArtifact(context.Background(), "owner/repo", 3, &out); err == nil {
+		t.Fatal("DownloadArtifact succeeded, want the read error")
+	}
+	if *calls != 1 {
+		t.Errorf("calls = %d, want 1", *calls)
+	}
+}
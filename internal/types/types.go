@@ -1,6 +1,9 @@
 package types
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // WorkflowRun represents a GitHub Actions workflow run
 type WorkflowRun struct {
@@ -20,6 +23,16 @@ type WorkflowRun struct {
 	UpdatedAt    time.Time  `json:"updated_at"`
 	RunStartedAt time.Time  `json:"run_started_at"`
 	Repository   Repository `json:"repository"`
+	// HeadRepository differs from Repository for pull requests from forks.
+	HeadRepository  Repository `json:"head_repository"`
+	Event           string     `json:"event"` // e.g. "push", "pull_request"
+	Actor           User       `json:"actor"`
+	TriggeringActor User       `json:"triggering_actor"`
+}
+
+// User is a GitHub account
+type User struct {
+	Login string `json:"login"`
 }
 
 // Repository represents a GitHub repository
@@ -49,6 +62,55 @@ type Job struct {
 	Steps       []Step    `json:"steps"`
 }
 
+// Artifact represents a file uploaded by a workflow run
+type Artifact struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	SizeInBytes int64     `json:"size_in_bytes"`
+	Expired     bool      `json:"expired"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// ArtifactsResponse is the API response for listing a run's artifacts
+type ArtifactsResponse struct {
+	TotalCount int        `json:"total_count"`
+	Artifacts  []Artifact `json:"artifacts"`
+}
+
+// PendingDeployment is an environment a waiting run needs approval to deploy to
+type PendingDeployment struct {
+	Environment           Environment          `json:"environment"`
+	WaitTimer             int                  `json:"wait_timer"` // minutes
+	WaitTimerStartedAt    time.Time            `json:"wait_timer_started_at"`
+	CurrentUserCanApprove bool                 `json:"current_user_can_approve"`
+	Reviewers             []DeploymentReviewer `json:"reviewers"`
+}
+
+// Environment is a deployment environment such as "production"
+type Environment struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	HTMLURL string `json:"html_url"`
+}
+
+// DeploymentReviewer is a user or team required to review a deployment
+type DeploymentReviewer struct {
+	Type     string `json:"type"` // "User" or "Team"
+	Reviewer struct {
+		Login string `json:"login"` // users
+		Slug  string `json:"slug"`  // teams
+	} `json:"reviewer"`
+}
+
+// Name returns the reviewer's login, or the team slug prefixed with "@".
+func (r DeploymentReviewer) Name() string {
+	if r.Type == "Team" {
+		return "@" + r.Reviewer.Slug
+	}
+	return r.Reviewer.Login
+}
+
 // Step represents a step within a job
 type Step struct {
 	Name        string    `json:"name"`
@@ -67,8 +129,55 @@ type JobsResponse struct {
 
 // WorkflowDef is a locally-discovered workflow file (may have no runs yet)
 type WorkflowDef struct {
-	Name string // from the "name:" YAML field; falls back to filename sans extension
-	File string // e.g. "ci.yaml"
+	Name   string          // from the "name:" YAML field; falls back to filename sans extension
+	File   string          // e.g. "ci.yaml"
+	Inputs []DispatchInput // on.workflow_dispatch.inputs, in declaration order
+}
+
+// Workflow is a workflow registered with GitHub Actions
+type Workflow struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Path    string `json:"path"`  // e.g. ".github/workflows/ci.yaml"
+	State   string `json:"state"` // one of the WorkflowState* constants
+	HTMLURL string `json:"html_url"`
+}
+
+// WorkflowsResponse is the API response for listing workflows
+type WorkflowsResponse struct {
+	TotalCount int        `json:"total_count"`
+	Workflows  []Workflow `json:"workflows"`
+}
+
+// Workflow state values as returned by the GitHub API (Workflow.State).
+const (
+	WorkflowStateActive             = "active"
+	WorkflowStateDisabledManually   = "disabled_manually"
+	WorkflowStateDisabledInactivity = "disabled_inactivity" // scheduled workflows after 60 days without repo activity
+)
+
+// Disabled reports whether the workflow's triggers no longer start runs.
+func (w *Workflow) Disabled() bool {
+	return strings.HasPrefix(w.State, "disabled")
+}
+
+// Input types accepted by workflow_dispatch (DispatchInput.Type).
+const (
+	InputTypeString      = "string"
+	InputTypeBoolean     = "boolean"
+	InputTypeChoice      = "choice"
+	InputTypeNumber      = "number"
+	InputTypeEnvironment = "environment"
+)
+
+// DispatchInput is a workflow_dispatch input declared in a workflow file
+type DispatchInput struct {
+	Name        string
+	Description string
+	Type        string // one of the InputType* constants; defaults to InputTypeString
+	Default     string
+	Required    bool
+	Options     []string // allowed values for InputTypeChoice
 }
 
 // Run status values as returned by the GitHub API (WorkflowRun.Status).
@@ -76,6 +185,22 @@ const (
 	RunStatusQueued     = "queued"
 	RunStatusInProgress = "in_progress"
 	RunStatusCompleted  = "completed"
+	RunStatusWaiting    = "waiting" // held by an environment protection rule
+	// RunStatusActionRequired marks a run from a fork pull request held
+	// until a maintainer approves it.
+	RunStatusActionRequired = "action_required"
+)
+
+// Run and job conclusion values as returned by the GitHub API.
+const (
+	ConclusionSuccess   = "success"
+	ConclusionFailure   = "failure"
+	ConclusionCancelled = "cancelled"
+	ConclusionTimedOut  = "timed_out"
+	ConclusionSkipped   = "skipped"
+	// ConclusionActionRequired marks a run from a fork pull request that
+	// needs a maintainer's approval before it runs.
+	ConclusionActionRequired = "action_required"
 )
 
 // StatusFilter represents the filter options for workflow run status
@@ -96,6 +221,17 @@ func (r *WorkflowRun) GetStatus() string {
 	return r.Status
 }
 
+// NeedsApproval reports whether the run is held until a maintainer approves
+// it, as for first-time contributors' fork pull requests.
+func (r *WorkflowRun) NeedsApproval() bool {
+	return r.Conclusion == ConclusionActionRequired || r.Status == RunStatusActionRequired
+}
+
+// IsFork reports whether the run's head commit comes from another repository.
+func (r *WorkflowRun) IsFork() bool {
+	return r.HeadRepository.FullName != "" && r.HeadRepository.FullName != r.Repository.FullName
+}
+
 // Duration returns the duration of the workflow run
 func (r *WorkflowRun) Duration() time.Duration {
 	if r.Status == RunStatusCompleted {
@@ -41,3 +41,36 @@ func TestWorkflowRunDuration(t *testing.T) {
 		t.Errorf("Duration() = %v, want %v", got, want)
 	}
 }
+
+func TestWorkflowRunNeedsApproval(t *testing.T) {
+	tests := []struct {
+		run  WorkflowRun
+		want bool
+	}{
+		{WorkflowRun{Status: RunStatusCompleted, Conclusion: ConclusionActionRequired}, true},
+		{WorkflowRun{Status: RunStatusActionRequired}, true},
+		{WorkflowRun{Status: RunStatusCompleted, Conclusion: ConclusionFailure}, false},
+		{WorkflowRun{Status: RunStatusWaiting}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.run.NeedsApproval(); got != tt.want {
+			t.Errorf("NeedsApproval() with status=%q conclusion=%q = %t, want %t",
+				tt.run.Status, tt.run.Conclusion, got, tt.want)
+		}
+	}
+}
+
+func TestWorkflowRunIsFork(t *testing.T) {
+	r := WorkflowRun{Repository: Repository{FullName: "o/r"}}
+	if r.IsFork() {
+		t.Error("IsFork() without a head repository = true")
+	}
+	r.HeadRepository.FullName = "o/r"
+	if r.IsFork() {
+		t.Error("IsFork() for the same repository = true")
+	}
+	r.HeadRepository.FullName = "contributor/r"
+	if !r.IsFork() {
+		t.Error("IsFork() for another repository = false")
+	}
+}
@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sgrStyle is the text style set by ANSI SGR ("select graphic rendition")
+// escape sequences, e.g. "\x1b[1;31m" for bold red.
+type sgrStyle struct {
+	fg, bg    string // lipgloss colors: an ANSI index ("1"), or "#rrggbb"; "" = default
+	bold      bool
+	faint     bool
+	italic    bool
+	underline bool
+	reverse   bool
+}
+
+// ansiSegment is a run of visible text in one style.
+type ansiSegment struct {
+	text  string
+	style sgrStyle
+}
+
+// parseANSI splits line into styled segments of visible text. SGR
+// sequences set the style of the text after them; other escape sequences,
+// e.g. cursor movement, are dropped.
+func parseANSI(line string) []ansiSegment {
+	if !strings.Contains(line, "\x1b") {
+		return []ansiSegment{{text: line}}
+	}
+	var segs []ansiSegment
+	var style sgrStyle
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			segs = append(segs, ansiSegment{text: text.String(), style: style})
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(line); {
+		if line[i] != '\x1b' {
+			j := strings.IndexByte(line[i:], '\x1b')
+			if j < 0 {
+				j = len(line) - i
+			}
+			text.WriteString(line[i : i+j])
+			i += j
+			continue
+		}
+		if i+1 >= len(line) || line[i+1] != '[' {
+			i++ // lone ESC
+			continue
+		}
+		// CSI: parameter and intermediate bytes, then a final byte in @–~
+		j := i + 2
+		for j < len(line) && (line[j] < '@' || line[j] > '~') {
+			j++
+		}
+		if j == len(line) {
+			break // cut off mid-sequence
+		}
+		if line[j] == 'm' {
+			flush()
+			style = style.apply(line[i+2 : j])
+		}
+		i = j + 1
+	}
+	flush()
+	return segs
+}
+
+// apply returns s updated by the parameters of an SGR sequence, e.g. "1;31".
+func (s sgrStyle) apply(params string) sgrStyle {
+	codes := strings.Split(params, ";")
+	for k := 0; k < len(codes); k++ {
+		code, err := strconv.Atoi(codes[k])
+		if err != nil {
+			code = 0 // an empty parameter is a reset
+		}
+		switch {
+		case code == 0:
+			s = sgrStyle{}
+		case code == 1:
+			s.bold = true
+		case code == 2:
+			s.faint = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = true
+		case code == 7:
+			s.reverse = true
+		case code == 22:
+			s.bold, s.faint = false, false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code == 27:
+			s.reverse = false
+		case code >= 30 && code <= 37:
+			s.fg = strconv.Itoa(code - 30)
+		case code >= 90 && code <= 97:
+			s.fg = strconv.Itoa(code - 90 + 8)
+		case code >= 40 && code <= 47:
+			s.bg = strconv.Itoa(code - 40)
+		case code >= 100 && code <= 107:
+			s.bg = strconv.Itoa(code - 100 + 8)
+		case code == 39:
+			s.fg = ""
+		case code == 49:
+			s.bg = ""
+		case code == 38 || code == 48:
+			var color string
+			color, k = extendedColor(codes, k)
+			if code == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+		}
+	}
+	return s
+}
+
+// extendedColor reads a 256-color ("5;n") or truecolor ("2;r;g;b") color
+// following the 38 or 48 at codes[k], returning it and the index of its
+// last parameter.
+func extendedColor(codes []string, k int) (string, int) {
+	if k+1 >= len(codes) {
+		return "", k
+	}
+	switch codes[k+1] {
+	case "5":
+		if k+2 < len(codes) {
+			return codes[k+2], k + 2
+		}
+	case "2":
+		if k+4 < len(codes) {
+			rgb := make([]int, 3)
+			for c := range rgb {
+				rgb[c], _ = strconv.Atoi(codes[k+2+c])
+			}
+			return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2]), k + 4
+		}
+	}
+	return "", len(codes)
+}
+
+// stripANSI returns the visible text of line.
+func stripANSI(line string) string {
+	if !strings.Contains(line, "\x1b") {
+		return line
+	}
+	var sb strings.Builder
+	for _, seg := range parseANSI(line) {
+		sb.WriteString(seg.text)
+	}
+	return sb.String()
+}
+
+// truncateSegments cuts segs to width visible columns, ending in "..."
+// when anything was cut, as gh.TruncateString does for plain text.
+func truncateSegments(segs []ansiSegment, width int) []ansiSegment {
+	total := 0
+	for _, seg := range segs {
+		total += lipgloss.Width(seg.text)
+	}
+	if total <= width {
+		return segs
+	}
+	ellipsis := "..."
+	if width <= len(ellipsis) {
+		ellipsis = ""
+	}
+	room := width - len(ellipsis)
+	var out []ansiSegment
+	for _, seg := range segs {
+		var sb strings.Builder
+		for _, r := range seg.text {
+			w := lipgloss.Width(string(r))
+			if w > room {
+				room = 0
+				break
+			}
+			sb.WriteRune(r)
+			room -= w
+		}
+		if sb.Len() > 0 {
+			out = append(out, ansiSegment{text: sb.String(), style: seg.style})
+		}
+		if room == 0 {
+			break
+		}
+	}
+	if ellipsis != "" {
+		var style sgrStyle
+		if len(out) > 0 {
+			style = out[len(out)-1].style
+		}
+		out = append(out, ansiSegment{text: ellipsis, style: style})
+	}
+	return out
+}
+
+// renderSegments renders segs over base, the style of the log view, with
+// each segment's own colors and attributes on top.
+func renderSegments(segs []ansiSegment, base lipgloss.Style) string {
+	var sb strings.Builder
+	for _, seg := range segs {
+		style := base
+		if seg.style.fg != "" {
+			style = style.Foreground(lipgloss.Color(seg.style.fg))
+		}
+		if seg.style.bg != "" {
+			style = style.Background(lipgloss.Color(seg.style.bg))
+		}
+		if seg.style.bold {
+			style = style.Bold(true)
+		}
+		if seg.style.faint {
+			style = style.Faint(true)
+		}
+		if seg.style.italic {
+			style = style.Italic(true)
+		}
+		if seg.style.underline {
+			style = style.Underline(true)
+		}
+		if seg.style.reverse {
+			style = style.Reverse(true)
+		}
+		sb.WriteString(style.Render(seg.text))
+	}
+	return sb.String()
+}
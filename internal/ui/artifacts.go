@@ -0,0 +1,315 @@
+package ui
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/turkosaurus/gh-ci/internal/gh"
+	"github.com/turkosaurus/gh-ci/internal/types"
+	"github.com/turkosaurus/gh-ci/internal/ui/styles"
+)
+
+// selectedArtifact returns the artifact under the detail cursor, which moves
+// through the jobs and then the artifacts, or nil when it is on a job.
+func (m Model) selectedArtifact() *types.Artifact {
+	i := m.jobCursor - len(m.jobs)
+	if i >= 0 && i < len(m.artifacts) {
+		return &m.artifacts[i]
+	}
+	return nil
+}
+
+// artifactFileName is the name a downloaded artifact is saved under; the ID
+// keeps artifacts of the same name from different runs apart.
+func artifactFileName(a types.Artifact) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, a.Name)
+	return fmt.Sprintf("%s-%d.zip", name, a.ID)
+}
+
+// downloadArtifact streams an artifact into the download directory. It is
+// written to a temporary file first, so a failed download leaves nothing
+// behind under the artifact's name.
+func (m Model) downloadArtifact(repo string, a types.Artifact) tea.Cmd {
+	dir := m.config.DownloadDir
+	return func() tea.Msg {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return downloadResultMsg{err: err}
+		}
+		f, err := os.CreateTemp(dir, ".download-*")
+		if err != nil {
+			return downloadResultMsg{err: err}
+		}
+		defer os.Remove(f.Name()) // no-op after the rename
+		// artifacts can be far larger than any API response, so they have
+		// a timeout of their own
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.config.DownloadTimeout)*time.Second)
+		defer cancel()
+		err = m.client.DownloadArtifact(ctx, repo, a.ID, f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return downloadResultMsg{err: err}
+		}
+		if err := os.Chmod(f.Name(), 0o644); err != nil {
+			return downloadResultMsg{err: err}
+		}
+		path := filepath.Join(dir, artifactFileName(a))
+		if err := os.Rename(f.Name(), path); err != nil {
+			return downloadResultMsg{err: err}
+		}
+		return downloadResultMsg{path: path}
+	}
+}
+
+// formatExpiry describes how long until an artifact expires.
+func formatExpiry(a types.Artifact, now time.Time) string {
+	left := a.ExpiresAt.Sub(now)
+	switch {
+	case a.Expired || (!a.ExpiresAt.IsZero() && left <= 0):
+		return "expired"
+	case a.ExpiresAt.IsZero():
+		return ""
+	case left < time.Hour:
+		return "expires <1h"
+	case left < 48*time.Hour:
+		return fmt.Sprintf("expires %dh", int(left.Hours()))
+	}
+	return fmt.Sprintf("expires %dd", int(left.Hours()/24))
+}
+
+// renderArtifacts draws the artifacts section of the detail panel. Rows
+// continue the job rows' cursor positions.
+func renderArtifacts(m Model, width int, active bool) string {
+	if m.artifactsErr != nil {
+		return m.styles.Dimmed.Render("artifacts unavailable") + "\n"
+	}
+	if len(m.artifacts) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	headerStyle := m.styles.Dimmed
+	if active {
+		headerStyle = lipgloss.NewStyle().Foreground(styles.ColorPurple)
+	}
+	sb.WriteString(headerStyle.Render("artifacts") + "\n")
+
+	now := time.Now()
+	for i, a := range m.artifacts {
+		meta := gh.FormatSize(a.SizeInBytes)
+		if exp := formatExpiry(a, now); exp != "" {
+			meta += "  " + exp
+		}
+		name := gh.TruncateString(a.Name, max(4, width-lipgloss.Width(meta)-6))
+		line := fmt.Sprintf("  ▪ %s  %s", name, meta)
+
+		selected := m.jobCursor == len(m.jobs)+i
+		fg := styles.ColorWhite
+		if a.Expired {
+			fg = styles.ColorGray
+		}
+		switch {
+		case selected && active:
+			line = lipgloss.NewStyle().Bold(true).Background(styles.ColorBgLight).Foreground(fg).Render(line)
+		case selected:
+			line = lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPurple).Render(line)
+		case a.Expired:
+			line = m.styles.Dimmed.Render(line)
+		default:
+			line = "  ▪ " + m.styles.Normal.Render(name) + "  " + m.styles.Duration.Render(meta)
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
+
+const (
+	// maxArtifactEntrySize bounds how much of a zip entry is read for viewing.
+	maxArtifactEntrySize = 20 << 20
+	// maxArtifactViewSize bounds the artifacts the viewer downloads into
+	// memory; larger ones can still be downloaded to disk.
+	maxArtifactViewSize = 100 << 20
+	// artifactViewTimeouts is how many request timeouts the viewer allows
+	// for downloading an artifact.
+	artifactViewTimeouts = 4
+)
+
+// loadArtifactZip downloads an artifact into memory for the viewer.
+func (m Model) loadArtifactZip(repo string, a types.Artifact) tea.Cmd {
+	if a.SizeInBytes > maxArtifactViewSize {
+		err := fmt.Errorf("%s is too large to view (%s); download it instead", a.Name, gh.FormatSize(a.SizeInBytes))
+		return func() tea.Msg { return artifactLoadedMsg{artifactID: a.ID, err: err} }
+	}
+	return func() tea.Msg {
+		// the viewer is waited on, so it gets a few request timeouts rather
+		// than the download timeout
+		ctx, cancel := context.WithTimeout(context.Background(), artifactViewTimeouts*time.Duration(m.config.RequestTimeout)*time.Second)
+		defer cancel()
+		var buf bytes.Buffer
+		buf.Grow(int(a.SizeInBytes))
+		if err := m.client.DownloadArtifact(ctx, repo, a.ID, &buf); err != nil {
+			return artifactLoadedMsg{artifactID: a.ID, err: err}
+		}
+		data := buf.Bytes()
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return artifactLoadedMsg{artifactID: a.ID, err: fmt.Errorf("read zip: %w", err)}
+		}
+		var entries []*zip.File
+		for _, f := range zr.File {
+			if !f.FileInfo().IsDir() {
+				entries = append(entries, f)
+			}
+		}
+		return artifactLoadedMsg{artifactID: a.ID, name: a.Name, entries: entries}
+	}
+}
+
+// readTextEntry returns the contents of a zip entry, refusing entries that
+// are too large or do not look like text.
+func readTextEntry(f *zip.File) (string, error) {
+	if f.UncompressedSize64 > maxArtifactEntrySize {
+		return "", fmt.Errorf("%s is too large to view (%s)", f.Name, gh.FormatSize(int64(f.UncompressedSize64)))
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxArtifactEntrySize))
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", f.Name, err)
+	}
+	if !isText(data) {
+		return "", fmt.Errorf("%s is a binary file", f.Name)
+	}
+	return string(data), nil
+}
+
+// isText reports whether data looks like text: valid UTF-8 with no NUL bytes
+// in its first few kilobytes.
+func isText(data []byte) bool {
+	head := data[:min(len(data), 8000)]
+	if bytes.IndexByte(head, 0) >= 0 {
+		return false
+	}
+	// a multi-byte rune may be cut at the end of head
+	for i := 0; i < utf8.UTFMax && len(head) > 0 && !utf8.Valid(head); i++ {
+		head = head[:len(head)-1]
+	}
+	return utf8.Valid(head)
+}
+
+// closeArtifactEntry returns from a text entry to the artifact's entry list.
+func (m *Model) closeArtifactEntry() {
+	m.artifactEntryOpen = false
+	m.setLogs("", nil)
+	m.clearLogSearch()
+}
+
+// closeArtifact leaves the artifact viewer for the main screen.
+func (m *Model) closeArtifact() {
+	m.closeArtifactEntry()
+	m.artifactEntries = nil
+	m.artifactName = ""
+	m.artifactEntryCursor = 0
+	m.screen = ScreenMain
+}
+
+// handleArtifactEntries navigates an artifact's entry list in ScreenLogs.
+func (m Model) handleArtifactEntries(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Left), msg.Type == tea.KeyBackspace:
+		m.closeArtifact()
+	case key.Matches(msg, m.keys.Up):
+		m.artifactEntryCursor = max(0, m.artifactEntryCursor-1)
+	case key.Matches(msg, m.keys.Down):
+		m.artifactEntryCursor = min(len(m.artifactEntries)-1, m.artifactEntryCursor+1)
+	case key.Matches(msg, m.keys.Top):
+		m.artifactEntryCursor = 0
+	case key.Matches(msg, m.keys.Bottom):
+		m.artifactEntryCursor = max(0, len(m.artifactEntries)-1)
+	case key.Matches(msg, m.keys.Enter), key.Matches(msg, m.keys.Logs):
+		if m.artifactEntryCursor >= len(m.artifactEntries) {
+			break
+		}
+		f := m.artifactEntries[m.artifactEntryCursor]
+		text, err := readTextEntry(f)
+		if err != nil {
+			m.message = "cannot view: " + err.Error()
+			return m, clearMsg()
+		}
+		m.setLogs(text, nil)
+		m.logJobName = m.artifactName + "/" + f.Name
+		m.artifactEntryOpen = true
+	}
+	return m, nil
+}
+
+// renderArtifactEntries lists the files in an artifact opened in ScreenLogs.
+func renderArtifactEntries(m Model, w, h int) string {
+	var sb strings.Builder
+	header := fmt.Sprintf("Artifact: %s", m.artifactName)
+	count := fmt.Sprintf("%d files", len(m.artifactEntries))
+	hGap := max(1, w-len(header)-len(count)-2)
+	sb.WriteString(lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPurple).
+		Render(header + strings.Repeat(" ", hGap) + count))
+	sb.WriteString("\n\n")
+
+	visible := h - logViewOverhead
+	start := max(0, m.artifactEntryCursor-visible+1)
+	end := min(len(m.artifactEntries), start+visible)
+	if len(m.artifactEntries) == 0 {
+		sb.WriteString(m.styles.Dimmed.Render("artifact is empty") + "\n")
+	}
+	for i := start; i < end; i++ {
+		f := m.artifactEntries[i]
+		size := gh.FormatSize(int64(f.UncompressedSize64))
+		name := gh.TruncateString(f.Name, max(10, w-len(size)-6))
+		line := fmt.Sprintf("  %s  %s", name, size)
+		if i == m.artifactEntryCursor {
+			line = lipgloss.NewStyle().Bold(true).Background(styles.ColorBgLight).Foreground(styles.ColorWhite).Render(line)
+		} else {
+			line = "  " + m.styles.Normal.Render(name) + "  " + m.styles.Duration.Render(size)
+		}
+		sb.WriteString(line + "\n")
+	}
+	for i := end - start; i < visible; i++ {
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	if m.message != "" {
+		sb.WriteString(m.styles.Dimmed.Render(m.message))
+		return sb.String()
+	}
+	helpItems := []string{
+		bindingHelp(m.styles, m.keys.Up),
+		bindingHelp(m.styles, m.keys.Down),
+		m.styles.HelpKey.Render("↵") + " " + m.styles.HelpDesc.Render("view file"),
+		m.styles.HelpKey.Render("h/esc/⌫") + " " + m.styles.HelpDesc.Render("back"),
+		bindingHelp(m.styles, m.keys.Quit),
+	}
+	sb.WriteString(m.styles.Dimmed.Render(strings.Join(helpItems, "  ")))
+	return sb.String()
+}
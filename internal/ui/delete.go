@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/turkosaurus/gh-ci/internal/types"
+)
+
+// startDelete opens the confirmation to delete runs, or only their logs.
+// The user has to type the run number, or for several runs their count.
+func (m Model) startDelete(runs []types.WorkflowRun) (tea.Model, tea.Cmd) {
+	if m.deleteQueue != nil {
+		m.message = "a deletion is already in progress"
+		return m, clearMsg()
+	}
+	m.deleteConfirming = true
+	m.deleteRuns = runs
+	m.deleteLogsOnly = false
+	m.deleteErr = ""
+	m.deleteInput.SetValue("")
+	return m, m.deleteInput.Focus()
+}
+
+// deleteTarget is what must be typed to confirm deleting m.deleteRuns.
+func (m Model) deleteTarget() string {
+	if len(m.deleteRuns) == 1 {
+		return strconv.Itoa(m.deleteRuns[0].RunNumber)
+	}
+	return strconv.Itoa(len(m.deleteRuns))
+}
+
+// handleDeleteConfirm takes the typed confirmation; tab switches between
+// deleting the runs and deleting only their logs.
+func (m Model) handleDeleteConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEscape:
+		m.deleteConfirming = false
+		m.deleteInput.Blur()
+		return m, nil
+	case tea.KeyTab:
+		m.deleteLogsOnly = !m.deleteLogsOnly
+		return m, nil
+	case tea.KeyEnter:
+		if strings.TrimSpace(m.deleteInput.Value()) != m.deleteTarget() {
+			m.deleteErr = "type " + m.deleteTarget() + " to confirm"
+			return m, nil
+		}
+		m.deleteConfirming = false
+		m.deleteInput.Blur()
+		m.deleteQueue = m.deleteRuns
+		m.deleteRuns = nil
+		m.deleteDone = 0
+		m.deleteFailed = nil
+		m.message = m.deleteProgressMessage()
+		return m, m.deleteNext()
+	}
+	var cmd tea.Cmd
+	m.deleteInput, cmd = m.deleteInput.Update(msg)
+	m.deleteErr = ""
+	return m, cmd
+}
+
+// deleteNext deletes the next queued run, or its logs.
+func (m Model) deleteNext() tea.Cmd {
+	run := m.deleteQueue[m.deleteDone]
+	logsOnly := m.deleteLogsOnly
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		var err error
+		if logsOnly {
+			err = m.client.DeleteRunLogs(ctx, run.Repository.FullName, run.ID)
+		} else {
+			err = m.client.DeleteRun(ctx, run.Repository.FullName, run.ID)
+		}
+		return runDeletedMsg{runID: run.ID, err: err}
+	}
+}
+
+// deleteProgress records the outcome for the run at the head of the queue
+// and moves on to the next one, summarising once the queue is done.
+func (m *Model) deleteProgress(msg runDeletedMsg) tea.Cmd {
+	if m.deleteQueue == nil || m.deleteQueue[m.deleteDone].ID != msg.runID {
+		return nil
+	}
+	run := m.deleteQueue[m.deleteDone]
+	m.deleteDone++
+	if msg.err != nil {
+		slog.Debug("failed to delete run", "repo", run.Repository.FullName, "run", run.ID, "logsOnly", m.deleteLogsOnly, "err", msg.err)
+		m.deleteFailed = append(m.deleteFailed, deleteFailure{runNumber: run.RunNumber, err: msg.err})
+	} else if !m.deleteLogsOnly {
+		m.removeRun(run.ID)
+	}
+	if m.deleteDone < len(m.deleteQueue) {
+		m.message = m.deleteProgressMessage()
+		return m.deleteNext()
+	}
+
+	m.message = m.deleteSummary()
+	m.deleteQueue = nil
+	cmds := []tea.Cmd{clearMsg(), m.loadRuns()}
+	if !m.deleteLogsOnly {
+		cmds = append(cmds, m.applyFilter(), m.runChanged())
+	}
+	return tea.Batch(cmds...)
+}
+
+// deleteFailure is a run that could not be deleted.
+type deleteFailure struct {
+	runNumber int
+	err       error
+}
+
+func (m Model) deleteNoun() string {
+	if m.deleteLogsOnly {
+		return "logs of "
+	}
+	return ""
+}
+
+// deleteProgressMessage reports how far a deletion has got.
+func (m Model) deleteProgressMessage() string {
+	run := m.deleteQueue[m.deleteDone]
+	msg := fmt.Sprintf("deleting %srun #%d (%d/%d)...", m.deleteNoun(), run.RunNumber, m.deleteDone+1, len(m.deleteQueue))
+	if n := len(m.deleteFailed); n > 0 {
+		msg += fmt.Sprintf(" %d failed", n)
+	}
+	return msg
+}
+
+// deleteSummary reports a finished deletion, naming the runs that failed
+// and the first failure's error.
+func (m Model) deleteSummary() string {
+	total := len(m.deleteQueue)
+	if len(m.deleteFailed) == 0 {
+		if total == 1 {
+			return fmt.Sprintf("deleted %srun #%d", m.deleteNoun(), m.deleteQueue[0].RunNumber)
+		}
+		return fmt.Sprintf("deleted %s%d runs", m.deleteNoun(), total)
+	}
+	first := m.deleteFailed[0]
+	if total == 1 {
+		return errorMessage(actionDelete, fmt.Errorf("run #%d: %w", first.runNumber, first.err))
+	}
+	failed := make([]string, len(m.deleteFailed))
+	for i, f := range m.deleteFailed {
+		failed[i] = "#" + strconv.Itoa(f.runNumber)
+	}
+	return fmt.Sprintf("deleted %s%d of %d runs; failed %s: %s", m.deleteNoun(), total-len(m.deleteFailed), total,
+		strings.Join(failed, ", "), errorMessage(actionDelete, first.err))
+}
+
+// removeRun drops a deleted run from the loaded runs; a refresh would keep
+// it, since mergeRuns retains runs missing from the first page.
+func (m *Model) removeRun(runID int64) {
+	kept := m.allRuns[:0:0]
+	for _, r := range m.allRuns {
+		if r.ID != runID {
+			kept = append(kept, r)
+		}
+	}
+	m.allRuns = kept
+}
+
+// renderDeleteConfirm is the help bar while confirming a deletion.
+func renderDeleteConfirm(m Model) string {
+	var what string
+	if len(m.deleteRuns) == 1 {
+		run := m.deleteRuns[0]
+		what = fmt.Sprintf("delete %srun #%d of %s in %s?", m.deleteNoun(), run.RunNumber, run.Name, run.Repository.FullName)
+	} else {
+		what = fmt.Sprintf("delete %s%d runs of %s?", m.deleteNoun(), len(m.deleteRuns), m.deleteRuns[0].Name)
+	}
+	mode := "logs only"
+	if m.deleteLogsOnly {
+		mode = "whole run"
+	}
+	s := m.styles.Error.Render(what) + " " + m.styles.Normal.Render("type "+m.deleteTarget()+":") + " " + m.deleteInput.View()
+	if m.deleteErr != "" {
+		s += " " + m.styles.Error.Render(m.deleteErr)
+	}
+	return s + "  " +
+		m.styles.HelpKey.Render("tab") + " " + m.styles.HelpDesc.Render(mode) + "  " +
+		m.styles.HelpKey.Render("↵") + " " + m.styles.HelpDesc.Render("delete") + "  " +
+		m.styles.HelpKey.Render("esc") + " " + m.styles.HelpDesc.Render("cancel")
+}
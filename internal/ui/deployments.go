@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/turkosaurus/gh-ci/internal/gh"
+	"github.com/turkosaurus/gh-ci/internal/types"
+	"github.com/turkosaurus/gh-ci/internal/ui/styles"
+)
+
+// startReview opens the approve/reject confirmation for the environments of
+// a waiting run that the user is allowed to review.
+func (m Model) startReview(run types.WorkflowRun) (tea.Model, tea.Cmd) {
+	var envs []types.Environment
+	for _, d := range m.deployments {
+		if d.CurrentUserCanApprove {
+			envs = append(envs, d.Environment)
+		}
+	}
+	if len(envs) == 0 {
+		if len(m.deployments) == 0 {
+			m.message = "no pending deployments for this run"
+		} else {
+			m.message = "you are not a required reviewer for these environments"
+		}
+		return m, clearMsg()
+	}
+	m.reviewConfirming = true
+	m.reviewRepo = run.Repository.FullName
+	m.reviewRunID = run.ID
+	m.reviewEnvs = envs
+	m.reviewDecided = false
+	m.reviewComment.SetValue("")
+	return m, nil
+}
+
+// handleReviewConfirm picks approve or reject, then takes an optional
+// comment before submitting the review.
+func (m Model) handleReviewConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !m.reviewDecided {
+		switch msg.String() {
+		case "y", "x":
+			m.reviewDecided = true
+			m.reviewApprove = msg.String() == "y"
+			return m, m.reviewComment.Focus()
+		case "esc", "q":
+			m.reviewConfirming = false
+		}
+		return m, nil
+	}
+
+	switch msg.Type {
+	case tea.KeyEscape:
+		m.reviewConfirming = false
+		m.reviewComment.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		m.reviewConfirming = false
+		m.reviewComment.Blur()
+		ids := make([]int64, len(m.reviewEnvs))
+		for i, env := range m.reviewEnvs {
+			ids[i] = env.ID
+		}
+		if m.reviewApprove {
+			m.message = "approving..."
+		} else {
+			m.message = "rejecting..."
+		}
+		return m, m.reviewDeployments(m.reviewRepo, m.reviewRunID, ids, m.reviewApprove, strings.TrimSpace(m.reviewComment.Value()))
+	}
+	var cmd tea.Cmd
+	m.reviewComment, cmd = m.reviewComment.Update(msg)
+	return m, cmd
+}
+
+func (m Model) reviewDeployments(repo string, runID int64, envIDs []int64, approve bool, comment string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		err := m.client.ReviewPendingDeployments(ctx, repo, runID, envIDs, approve, comment)
+		if err != nil {
+			return actionResultMsg{action: actionReview, err: err}
+		}
+		if approve {
+			return actionResultMsg{message: "deployment approved"}
+		}
+		return actionResultMsg{message: "deployment rejected"}
+	}
+}
+
+// envNames joins environment names for prompts.
+func envNames(envs []types.Environment) string {
+	names := make([]string, len(envs))
+	for i, env := range envs {
+		names[i] = env.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// renderReviewConfirm is the help bar while reviewing pending deployments.
+func renderReviewConfirm(m Model) string {
+	envs := gh.TruncateString(envNames(m.reviewEnvs), 40)
+	if !m.reviewDecided {
+		return m.styles.Normal.Render("deploy to "+envs+"?") + "  " +
+			m.styles.HelpKey.Render("y") + " " + m.styles.HelpDesc.Render("approve") + "  " +
+			m.styles.HelpKey.Render("x") + " " + m.styles.HelpDesc.Render("reject") + "  " +
+			m.styles.HelpKey.Render("esc") + " " + m.styles.HelpDesc.Render("cancel")
+	}
+	verb := "reject"
+	if m.reviewApprove {
+		verb = "approve"
+	}
+	return m.styles.Normal.Render(verb+" "+envs+":") + " " + m.reviewComment.View() + "  " +
+		m.styles.HelpKey.Render("↵") + " " + m.styles.HelpDesc.Render(verb) + "  " +
+		m.styles.HelpKey.Render("esc") + " " + m.styles.HelpDesc.Render("cancel")
+}
+
+// renderDeployments lists the environments a waiting run is held on, with
+// their required reviewers, for the detail panel.
+func renderDeployments(m Model, width int) string {
+	if len(m.deployments) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(lipgloss.NewStyle().Foreground(styles.ColorYellow).Render("waiting on") + "\n")
+	for _, d := range m.deployments {
+		env := "  " + styles.StatusIcon(types.RunStatusWaiting, "") + " " + gh.TruncateString(d.Environment.Name, width-5)
+		if d.CurrentUserCanApprove {
+			sb.WriteString(m.styles.Normal.Render(env) + "\n")
+		} else {
+			sb.WriteString(m.styles.Dimmed.Render(env) + "\n")
+		}
+
+		var reviewers []string
+		for _, r := range d.Reviewers {
+			reviewers = append(reviewers, r.Name())
+		}
+		switch {
+		case len(reviewers) > 0:
+			sb.WriteString(m.styles.Dimmed.Render("    "+gh.TruncateString("by "+strings.Join(reviewers, ", "), width-5)) + "\n")
+		case d.WaitTimer > 0:
+			sb.WriteString(m.styles.Dimmed.Render(fmt.Sprintf("    wait timer %dm", d.WaitTimer)) + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// handleApproveConfirm confirms letting a fork pull request's run proceed.
+func (m Model) handleApproveConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y":
+		m.approveConfirming = false
+		m.message = "approving..."
+		return m, m.approveRunCmd(m.approveRun.Repository.FullName, m.approveRun.ID)
+	case "esc", "q":
+		m.approveConfirming = false
+	}
+	return m, nil
+}
+
+func (m Model) approveRunCmd(repo string, runID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		if err := m.client.ApproveRun(ctx, repo, runID); err != nil {
+			return actionResultMsg{action: actionApprove, err: err}
+		}
+		return actionResultMsg{message: "run approved"}
+	}
+}
+
+// renderApproveConfirm is the help bar while approving a fork pull request's
+// run; it names who triggered the run and the repository the code comes
+// from so the maintainer knows whose code is about to run. The actor need
+// not be the pull request's author, e.g. after a re-run.
+func renderApproveConfirm(m Model) string {
+	run := m.approveRun
+	actor := run.TriggeringActor.Login
+	if actor == "" {
+		actor = run.Actor.Login
+	}
+	from := run.HeadRepository.FullName
+	if from == "" {
+		from = "unknown repository"
+	}
+	return m.styles.StatusAction.Render("run untrusted code") + " " +
+		m.styles.Normal.Render("triggered by "+actor+" from "+from+" on "+run.HeadBranch+"?") + "  " +
+		m.styles.HelpKey.Render("y") + " " + m.styles.HelpDesc.Render("approve") + "  " +
+		m.styles.HelpKey.Render("esc") + " " + m.styles.HelpDesc.Render("cancel")
+}
@@ -0,0 +1,302 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+
+	"github.com/turkosaurus/gh-ci/internal/gh"
+	"github.com/turkosaurus/gh-ci/internal/types"
+	"github.com/turkosaurus/gh-ci/internal/ui/styles"
+)
+
+// parseDispatchInputs extracts on.workflow_dispatch.inputs from a workflow's
+// "on:" node, preserving declaration order. Triggers given as a string or
+// list ("on: push", "on: [push, workflow_dispatch]") declare no inputs.
+func parseDispatchInputs(on *yaml.Node) ([]types.DispatchInput, error) {
+	dispatch := mappingValue(on, "workflow_dispatch")
+	inputs := mappingValue(dispatch, "inputs")
+	if inputs == nil || inputs.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var out []types.DispatchInput
+	for i := 0; i+1 < len(inputs.Content); i += 2 {
+		name, spec := inputs.Content[i].Value, inputs.Content[i+1]
+		var raw struct {
+			Description string    `yaml:"description"`
+			Type        string    `yaml:"type"`
+			Default     yaml.Node `yaml:"default"` // may be a bool or number scalar
+			Required    bool      `yaml:"required"`
+			Options     []string  `yaml:"options"`
+		}
+		if err := spec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("input %q: %w", name, err)
+		}
+		in := types.DispatchInput{
+			Name:        name,
+			Description: raw.Description,
+			Type:        raw.Type,
+			Default:     raw.Default.Value,
+			Required:    raw.Required,
+			Options:     raw.Options,
+		}
+		if in.Type == "" {
+			in.Type = types.InputTypeString
+		}
+		out = append(out, in)
+	}
+	return out, nil
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// initialDispatchValue is the form's starting value for an input.
+func initialDispatchValue(in types.DispatchInput) string {
+	switch {
+	case in.Default != "":
+		return in.Default
+	case in.Type == types.InputTypeBoolean:
+		return "false"
+	case in.Type == types.InputTypeChoice && len(in.Options) > 0:
+		return in.Options[0]
+	}
+	return ""
+}
+
+// validateDispatchInputs checks values against their inputs and returns the
+// payload to send. On failure it returns the index of the first bad input.
+// Empty optional inputs are omitted so the workflow's default applies.
+func validateDispatchInputs(inputs []types.DispatchInput, values []string) (map[string]string, int, error) {
+	payload := make(map[string]string, len(inputs))
+	for i, in := range inputs {
+		v := strings.TrimSpace(values[i])
+		if v == "" {
+			if in.Required {
+				return nil, i, fmt.Errorf("%s is required", in.Name)
+			}
+			continue
+		}
+		switch in.Type {
+		case types.InputTypeBoolean:
+			if v != "true" && v != "false" {
+				return nil, i, fmt.Errorf("%s must be true or false", in.Name)
+			}
+		case types.InputTypeNumber:
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return nil, i, fmt.Errorf("%s must be a number", in.Name)
+			}
+		case types.InputTypeChoice:
+			valid := false
+			for _, opt := range in.Options {
+				if v == opt {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return nil, i, fmt.Errorf("%s must be one of: %s", in.Name, strings.Join(in.Options, ", "))
+			}
+		}
+		payload[in.Name] = v
+	}
+	return payload, -1, nil
+}
+
+// dispatchInputsFor returns the inputs declared by the local definition of
+// workflow file, if any.
+func (m Model) dispatchInputsFor(file string) []types.DispatchInput {
+	for _, def := range m.localDefs {
+		if def.File == file {
+			return def.Inputs
+		}
+	}
+	return nil
+}
+
+// startDispatch opens the dispatch confirmation, with an input form when the
+// workflow declares workflow_dispatch inputs.
+func (m Model) startDispatch(repo, file, ref string) (tea.Model, tea.Cmd) {
+	m.dispatchConfirming = true
+	m.dispatchRepo = repo
+	m.dispatchFile = file
+	m.dispatchRef = ref
+	m.dispatchErr = ""
+	m.dispatchInputs = m.dispatchInputsFor(file)
+	m.dispatchValues = make([]string, len(m.dispatchInputs))
+	for i, in := range m.dispatchInputs {
+		m.dispatchValues[i] = initialDispatchValue(in)
+	}
+	if len(m.dispatchInputs) == 0 {
+		return m, nil
+	}
+	return m.focusDispatchField(0)
+}
+
+// focusDispatchField moves form focus to field i (wrapping), saving the value
+// being edited and loading the new field into the editor when it is text.
+func (m Model) focusDispatchField(i int) (tea.Model, tea.Cmd) {
+	n := len(m.dispatchInputs)
+	if m.dispatchEditor.Focused() {
+		m.dispatchValues[m.dispatchField] = m.dispatchEditor.Value()
+		m.dispatchEditor.Blur()
+	}
+	m.dispatchField = ((i % n) + n) % n
+	in := m.dispatchInputs[m.dispatchField]
+	if in.Type == types.InputTypeBoolean || in.Type == types.InputTypeChoice {
+		return m, nil
+	}
+	m.dispatchEditor.SetValue(m.dispatchValues[m.dispatchField])
+	m.dispatchEditor.CursorEnd()
+	return m, m.dispatchEditor.Focus()
+}
+
+// handleDispatchForm edits workflow_dispatch inputs before dispatching.
+func (m Model) handleDispatchForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEscape:
+		m.dispatchConfirming = false
+		m.dispatchEditor.Blur()
+		return m, nil
+	case tea.KeyTab, tea.KeyDown:
+		return m.focusDispatchField(m.dispatchField + 1)
+	case tea.KeyShiftTab, tea.KeyUp:
+		return m.focusDispatchField(m.dispatchField - 1)
+	case tea.KeyEnter:
+		if m.dispatchEditor.Focused() {
+			m.dispatchValues[m.dispatchField] = m.dispatchEditor.Value()
+		}
+		payload, bad, err := validateDispatchInputs(m.dispatchInputs, m.dispatchValues)
+		if err != nil {
+			m.dispatchErr = err.Error()
+			return m.focusDispatchField(bad)
+		}
+		m.dispatchConfirming = false
+		m.dispatchEditor.Blur()
+		m.message = "dispatching..."
+		return m, m.runDispatch(m.dispatchRepo, m.dispatchFile, m.dispatchRef, payload)
+	}
+
+	in := m.dispatchInputs[m.dispatchField]
+	value := &m.dispatchValues[m.dispatchField]
+	switch in.Type {
+	case types.InputTypeBoolean:
+		switch msg.String() {
+		case " ", "left", "right", "h", "l":
+			if *value == "true" {
+				*value = "false"
+			} else {
+				*value = "true"
+			}
+		}
+		return m, nil
+	case types.InputTypeChoice:
+		delta := 0
+		switch msg.String() {
+		case " ", "right", "l":
+			delta = 1
+		case "left", "h":
+			delta = -1
+		}
+		if delta != 0 && len(in.Options) > 0 {
+			idx := 0
+			for i, opt := range in.Options {
+				if opt == *value {
+					idx = i
+					break
+				}
+			}
+			n := len(in.Options)
+			*value = in.Options[((idx+delta)%n+n)%n]
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.dispatchEditor, cmd = m.dispatchEditor.Update(msg)
+	*value = m.dispatchEditor.Value()
+	m.dispatchErr = ""
+	return m, cmd
+}
+
+// renderDispatchForm draws the workflow_dispatch input form in place of the
+// runs list.
+func renderDispatchForm(m Model, width, height int) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPurple)
+	rows := []string{
+		titleStyle.Render("DISPATCH "+m.dispatchFile) + m.styles.Dimmed.Render(" on ") + m.styles.Branch.Render(m.dispatchRef),
+		"",
+	}
+
+	const labelW = 20
+	focusRow := 0
+	for i, in := range m.dispatchInputs {
+		focused := i == m.dispatchField
+		if focused {
+			focusRow = len(rows)
+		}
+		label := in.Name
+		if in.Required {
+			label += "*"
+		}
+		label = gh.PadString(label, labelW)
+
+		value := m.dispatchValues[i]
+		var field string
+		switch {
+		case in.Type == types.InputTypeBoolean:
+			box := "[ ]"
+			if value == "true" {
+				box = "[x]"
+			}
+			field = m.styles.Normal.Render(box + " " + value)
+		case in.Type == types.InputTypeChoice:
+			field = m.styles.Normal.Render("‹ " + value + " ›")
+			if focused {
+				field += m.styles.Dimmed.Render(fmt.Sprintf("  %s", strings.Join(in.Options, " | ")))
+			}
+		case focused:
+			field = m.dispatchEditor.View()
+		case value == "":
+			field = m.styles.Dimmed.Render("(empty)")
+		default:
+			field = m.styles.Normal.Render(gh.TruncateString(value, max(10, width-labelW-4)))
+		}
+
+		if focused {
+			rows = append(rows, lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPurple).Render("> "+label)+field)
+		} else {
+			rows = append(rows, "  "+m.styles.Normal.Render(label)+field)
+		}
+		if in.Description != "" {
+			rows = append(rows, "    "+m.styles.Dimmed.Render(gh.TruncateString(in.Description, max(10, width-4))))
+		}
+	}
+
+	if m.dispatchErr != "" {
+		rows = append(rows, "", m.styles.Error.Render(gh.TruncateString(m.dispatchErr, width)))
+	}
+	// scroll so the focused field stays visible
+	if start := focusRow - height + 2; start > 0 {
+		rows = rows[start:]
+	}
+	if len(rows) > height {
+		rows = rows[:height]
+	}
+	return strings.Join(rows, "\n")
+}
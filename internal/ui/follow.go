@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/turkosaurus/gh-ci/internal/gh"
+	"github.com/turkosaurus/gh-ci/internal/types"
+)
+
+// logFollowInterval is how often the logs of an in-progress job are
+// re-fetched while following. Each poll costs two requests: the job's
+// status and its logs.
+const logFollowInterval = 5 * time.Second
+
+// followLogs starts (or resumes) tailing the shown job's logs. Any tick
+// still pending from an earlier follow is invalidated by bumping the
+// sequence number.
+func (m *Model) followLogs() tea.Cmd {
+	m.logFollowing = true
+	m.logFollowSeq++
+	m.pinLogsToBottom()
+	return m.followTick()
+}
+
+// stopFollowing ends tailing, e.g. when leaving the log view.
+func (m *Model) stopFollowing() {
+	m.logFollowing = false
+	m.logJobActive = false
+	m.logFollowSeq++
+}
+
+func (m Model) followTick() tea.Cmd {
+	seq := m.logFollowSeq
+	return tea.Tick(logFollowInterval, func(time.Time) tea.Msg {
+		return logFollowTickMsg{seq: seq}
+	})
+}
+
+// fetchFollowedLogs re-fetches the followed job and its logs. The job is
+// fetched first, so logs fetched after it reports completed are complete.
+func (m Model) fetchFollowedLogs() tea.Cmd {
+	repo, jobID, seq := m.logRepo, m.logJobID, m.logFollowSeq
+	client := m.client
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		msg := logsFollowedMsg{seq: seq}
+		job, err := client.GetJob(ctx, repo, jobID)
+		if err != nil {
+			msg.err = err
+			msg.missing = gh.IsNotFound(err) || gh.IsGone(err)
+			return msg
+		}
+		msg.job = job
+		msg.logs, msg.err = client.GetJobLogs(ctx, repo, jobID)
+		return msg
+	}
+}
+
+// followTicked polls for new log lines, unless following was paused or
+// the quota is spent, in which case the next tick is scheduled regardless.
+func (m *Model) followTicked(msg logFollowTickMsg) tea.Cmd {
+	if msg.seq != m.logFollowSeq || !m.logFollowing {
+		return nil
+	}
+	if m.rateLimit.Exhausted(time.Now()) {
+		slog.Debug("rate limit exhausted; skipping log poll", "reset", m.rateLimit.Reset)
+		return m.followTick()
+	}
+	return m.fetchFollowedLogs()
+}
+
+// logsFollowed applies a poll: it appends the new lines, keeps the view at
+// the bottom, and stops following once the job has completed.
+func (m *Model) logsFollowed(msg logsFollowedMsg) tea.Cmd {
+	if msg.seq != m.logFollowSeq || !m.logFollowing {
+		return nil
+	}
+	if msg.missing {
+		m.logFollowing = false
+		m.logJobActive = false
+		m.message = "stopped following: the job no longer exists"
+		return clearMsg()
+	}
+	if msg.err != nil && msg.job.Status == types.RunStatusCompleted {
+		// the job finished without logs appearing, e.g. it was cancelled
+		// while queued
+		m.logFollowing = false
+		m.logJobActive = false
+		m.message = errorMessage(actionLoadLogs, msg.err)
+		return clearMsg()
+	}
+	if msg.err != nil {
+		// transient, or the job has not written its logs yet; try again on
+		// the next tick
+		slog.Debug("failed to follow logs", "repo", m.logRepo, "job", m.logJobID, "err", msg.err)
+		return m.followTick()
+	}
+	m.logJobSteps = msg.job.Steps
+	m.appendLogs(msg.logs)
+	m.pinLogsToBottom()
+	for i := range m.jobs {
+		if m.jobs[i].ID == msg.job.ID {
+			m.jobs[i] = msg.job
+		}
+	}
+	if msg.job.Status == types.RunStatusCompleted {
+		m.logFollowing = false
+		m.logJobActive = false
+		return nil
+	}
+	return m.followTick()
+}
+
+// appendLogs adds the lines of a re-fetched log that are not shown yet. A
+// log that no longer starts with the shown one (the job was re-run)
+// replaces it.
+func (m *Model) appendLogs(fresh string) {
+	if strings.HasPrefix(fresh, m.logs) {
+		m.logs += fresh[len(m.logs):]
+	} else {
+		m.logs = fresh
+		m.logGroupOpen = nil
+	}
+	m.parseLogs()
+	if m.logQuery != "" {
+		m.logContextLines, m.logMatchGroups = buildLogContext(strings.Split(m.logs, "\n"), m.logQuery, 3)
+	}
+}
+
+func (m *Model) pinLogsToBottom() {
+	m.moveLogCursor(m.logDisplayLen(), true)
+}
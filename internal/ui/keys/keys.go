@@ -10,6 +10,11 @@ type KeyMap struct {
 	Rerun        key.Binding
 	Cancel       key.Binding
 	Dispatch     key.Binding
+	Download     key.Binding
+	Approve      key.Binding
+	Toggle       key.Binding
+	Delete       key.Binding
+	DeleteAll    key.Binding
 	Logs         key.Binding
 	Open         key.Binding
 	Refresh      key.Binding
@@ -25,6 +30,16 @@ type KeyMap struct {
 	HalfPageDown key.Binding
 	Top          key.Binding
 	Bottom       key.Binding
+	Follow       key.Binding
+	Fold         key.Binding
+	UnfoldAll    key.Binding
+	FoldAll      key.Binding
+	Colors       key.Binding
+	NextStep     key.Binding
+	PrevStep     key.Binding
+	FailedStep   key.Binding
+	NextIssue    key.Binding
+	PrevIssue    key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings
@@ -54,6 +69,26 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("d"),
 			key.WithHelp("d", "dispatch"),
 		),
+		Download: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "save artifact"),
+		),
+		Approve: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "approve"),
+		),
+		Toggle: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "enable/disable"),
+		),
+		Delete: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "delete run"),
+		),
+		DeleteAll: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "delete all shown"),
+		),
 		Logs: key.NewBinding(
 			key.WithKeys("l", "right"),
 			key.WithHelp("l/→", "right"),
@@ -102,6 +137,46 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("G", "end"),
 			key.WithHelp("G", "bottom"),
 		),
+		Follow: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "follow"),
+		),
+		Fold: key.NewBinding(
+			key.WithKeys("enter", " "),
+			key.WithHelp("↵/space", "fold"),
+		),
+		UnfoldAll: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "expand all"),
+		),
+		FoldAll: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "collapse all"),
+		),
+		Colors: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "toggle colors"),
+		),
+		NextStep: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "next step"),
+		),
+		PrevStep: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "prev step"),
+		),
+		FailedStep: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "failed step"),
+		),
+		NextIssue: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "next error"),
+		),
+		PrevIssue: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "prev error"),
+		),
 		Search: key.NewBinding(
 			key.WithKeys("/"),
 			key.WithHelp("/", "search"),
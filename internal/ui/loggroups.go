@@ -0,0 +1,254 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/turkosaurus/gh-ci/internal/types"
+)
+
+// logGroup is a section of a job log between ##[group] and ##[endgroup]
+// markers, as written by the runner for each step's setup and by
+// `::group::` workflow commands. Groups may nest.
+type logGroup struct {
+	title        string
+	start        int  // line of the ##[group] marker
+	end          int  // line of the ##[endgroup] marker, or the last line while unterminated
+	unterminated bool // the log ends inside the group, e.g. a running step
+	depth        int  // number of enclosing groups
+}
+
+// lines is the number of log lines the group folds away.
+func (g logGroup) lines() int {
+	if g.unterminated {
+		return g.end - g.start
+	}
+	return g.end - g.start - 1
+}
+
+// logRow is one display row of the log view: a log line, or the header of
+// a group, which stands in for the group's lines while it is collapsed.
+type logRow struct {
+	line  int // index into the log lines
+	group int // index into logGroups when the line is a group header; -1 otherwise
+	depth int // number of expanded groups the line is in, for indentation
+}
+
+// splitLogTimestamp separates the RFC 3339 timestamp the runner prefixes
+// to every line of a job log from the rest of the line.
+func splitLogTimestamp(line string) (time.Time, string, bool) {
+	if ts, rest, ok := strings.Cut(line, " "); ok && strings.HasSuffix(ts, "Z") {
+		if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			return t, rest, true
+		}
+	}
+	return time.Time{}, line, false
+}
+
+// stripLogTimestamp drops the timestamp of a job log line.
+func stripLogTimestamp(line string) string {
+	_, rest, _ := splitLogTimestamp(line)
+	return rest
+}
+
+// logCommand reports whether line is the workflow command name, written by
+// the runner as ##[name] or echoed as ::name::, and returns its text.
+func logCommand(line, name string) (string, bool) {
+	line = stripLogTimestamp(stripANSI(line))
+	for _, prefix := range []string{"##[" + name + "]", "::" + name + "::"} {
+		if text, ok := strings.CutPrefix(line, prefix); ok {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// parseLogGroups finds the groups of a log in order of their headers. An
+// ##[endgroup] without a group is left as an ordinary line.
+func parseLogGroups(lines []string) []logGroup {
+	var groups []logGroup
+	var open []int // indexes into groups, innermost last
+	for i, line := range lines {
+		if title, ok := logCommand(line, "group"); ok {
+			open = append(open, len(groups))
+			groups = append(groups, logGroup{title: title, start: i, depth: len(open) - 1})
+			continue
+		}
+		if _, ok := logCommand(line, "endgroup"); ok && len(open) > 0 {
+			groups[open[len(open)-1]].end = i
+			open = open[:len(open)-1]
+		}
+	}
+	for _, gi := range open {
+		groups[gi].end = len(lines) - 1
+		groups[gi].unterminated = true
+	}
+	return groups
+}
+
+// buildLogRows lays out the display rows of a log, skipping the lines of
+// collapsed groups and the ##[endgroup] markers of expanded ones.
+func buildLogRows(n int, groups []logGroup, expanded func(gi int) bool) []logRow {
+	headers := make(map[int]int, len(groups))
+	ends := make(map[int]bool, len(groups))
+	for gi, g := range groups {
+		headers[g.start] = gi
+		if !g.unterminated {
+			ends[g.end] = true
+		}
+	}
+	rows := make([]logRow, 0, n)
+	var within []int // ends of the expanded groups around line i
+	for i := 0; i < n; i++ {
+		for len(within) > 0 && i > within[len(within)-1] {
+			within = within[:len(within)-1]
+		}
+		if gi, ok := headers[i]; ok {
+			rows = append(rows, logRow{line: i, group: gi, depth: len(within)})
+			if expanded(gi) {
+				within = append(within, groups[gi].end)
+			} else {
+				i = groups[gi].end
+			}
+			continue
+		}
+		if ends[i] {
+			continue
+		}
+		rows = append(rows, logRow{line: i, group: -1, depth: len(within)})
+	}
+	return rows
+}
+
+// setLogs shows text in the log view from the top, with its groups
+// collapsed. steps are the steps of the job that wrote the log, if any.
+func (m *Model) setLogs(text string, steps []types.Step) {
+	m.logs = text
+	m.logJobSteps = steps
+	m.logOffset = 0
+	m.logCursor = 0
+	m.logGroupOpen = nil
+	m.parseLogs()
+}
+
+// parseLogs finds the groups and steps of m.logs and lays out its rows,
+// keeping the groups' expanded state.
+func (m *Model) parseLogs() {
+	lines := strings.Split(m.logs, "\n")
+	m.logGroups = parseLogGroups(lines)
+	m.logSteps = splitLogSteps(lines, m.logJobSteps)
+	m.logIssues = findLogIssues(lines)
+	m.logRows = buildLogRows(len(lines), m.logGroups, m.groupExpanded)
+}
+
+// groupExpanded reports whether group gi is expanded. Groups start
+// collapsed, except one still being written, so a followed log shows its
+// tail.
+func (m Model) groupExpanded(gi int) bool {
+	g := m.logGroups[gi]
+	if open, ok := m.logGroupOpen[g.start]; ok {
+		return open
+	}
+	return g.unterminated
+}
+
+// setGroupExpanded expands or collapses group gi. State is kept by header
+// line, so it survives re-parsing a followed log.
+func (m *Model) setGroupExpanded(gi int, open bool) {
+	if m.logGroupOpen == nil {
+		m.logGroupOpen = make(map[int]bool)
+	}
+	m.logGroupOpen[m.logGroups[gi].start] = open
+}
+
+// relayoutLogs rebuilds the rows after groups were expanded or collapsed,
+// keeping the cursor on line, or on the header of the group now hiding it.
+func (m *Model) relayoutLogs(line int) {
+	m.logRows = buildLogRows(len(strings.Split(m.logs, "\n")), m.logGroups, m.groupExpanded)
+	m.logCursor = m.logRowOf(line)
+	m.moveLogCursor(0, false)
+}
+
+// logRowOf returns the row showing line: its own, or the header of the
+// collapsed group it is in.
+func (m Model) logRowOf(line int) int {
+	row := 0
+	for i, r := range m.logRows {
+		if r.line > line {
+			break
+		}
+		row = i
+	}
+	return row
+}
+
+// toggleGroup expands or collapses the group whose header is under the
+// cursor; on any other line it collapses the innermost group around it.
+func (m *Model) toggleGroup() {
+	if m.logCursor >= len(m.logRows) {
+		return
+	}
+	row := m.logRows[m.logCursor]
+	if row.group >= 0 {
+		m.setGroupExpanded(row.group, !m.groupExpanded(row.group))
+		m.relayoutLogs(row.line)
+		return
+	}
+	if gi := m.enclosingGroup(row.line); gi >= 0 {
+		m.setGroupExpanded(gi, false)
+		m.relayoutLogs(m.logGroups[gi].start)
+	}
+}
+
+// enclosingGroup returns the innermost group containing line, or -1.
+func (m Model) enclosingGroup(line int) int {
+	found := -1
+	for gi, g := range m.logGroups {
+		if g.start > line {
+			break
+		}
+		if line <= g.end {
+			found = gi // later headers are nested deeper
+		}
+	}
+	return found
+}
+
+// setAllGroupsExpanded expands or collapses every group.
+func (m *Model) setAllGroupsExpanded(open bool) {
+	if len(m.logGroups) == 0 {
+		return
+	}
+	line := 0
+	if m.logCursor < len(m.logRows) {
+		line = m.logRows[m.logCursor].line
+	}
+	for gi := range m.logGroups {
+		m.setGroupExpanded(gi, open)
+	}
+	m.relayoutLogs(line)
+}
+
+// expandMatchingGroups expands every group containing a line that matches
+// query, so search results are not hidden in collapsed groups.
+func (m *Model) expandMatchingGroups(query string) {
+	changed := false
+	for i, line := range strings.Split(m.logs, "\n") {
+		if !fuzzyMatch(stripANSI(line), query) {
+			continue
+		}
+		for gi, g := range m.logGroups {
+			if g.start < i && i <= g.end && !m.groupExpanded(gi) {
+				m.setGroupExpanded(gi, true)
+				changed = true
+			}
+		}
+	}
+	if changed {
+		line := 0
+		if m.logCursor < len(m.logRows) {
+			line = m.logRows[m.logCursor].line
+		}
+		m.relayoutLogs(line)
+	}
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// logLevel grades a log line as an error, a warning or neither.
+type logLevel int
+
+const (
+	levelNone logLevel = iota
+	levelWarning
+	levelError
+)
+
+// logIssue is an error or warning line of a log.
+type logIssue struct {
+	line  int
+	level logLevel
+}
+
+// lineLevel detects the annotations the runner writes for errors and
+// warnings, ##[error] and ##[warning], the ::error and ::warning workflow
+// commands (which may carry file=,line= parameters), and the failure output
+// of common tools: go test's FAIL lines and Go panics.
+func lineLevel(line string) logLevel {
+	text := stripLogTimestamp(stripANSI(line))
+	for _, l := range []struct {
+		name  string
+		level logLevel
+	}{{"error", levelError}, {"warning", levelWarning}} {
+		if strings.HasPrefix(text, "##["+l.name+"]") ||
+			strings.HasPrefix(text, "::"+l.name+"::") || strings.HasPrefix(text, "::"+l.name+" ") {
+			return l.level
+		}
+	}
+	text = strings.TrimLeft(text, " \t")
+	switch {
+	case strings.HasPrefix(text, "--- FAIL"), strings.HasPrefix(text, "FAIL\t"), text == "FAIL",
+		strings.HasPrefix(text, "panic:"):
+		return levelError
+	}
+	return levelNone
+}
+
+// findLogIssues lists the error and warning lines of a log in order.
+func findLogIssues(lines []string) []logIssue {
+	var issues []logIssue
+	for i, line := range lines {
+		if level := lineLevel(line); level != levelNone {
+			issues = append(issues, logIssue{line: i, level: level})
+		}
+	}
+	return issues
+}
+
+// logIssueLevel returns the level of line, by lookup in logIssues.
+func (m Model) logIssueLevel(line int) logLevel {
+	for _, issue := range m.logIssues {
+		if issue.line == line {
+			return issue.level
+		}
+		if issue.line > line {
+			break
+		}
+	}
+	return levelNone
+}
+
+// countLogIssues returns the number of errors and of warnings in lines
+// [from, to].
+func (m Model) countLogIssues(from, to int) (errors, warnings int) {
+	for _, issue := range m.logIssues {
+		if issue.line < from || issue.line > to {
+			continue
+		}
+		if issue.level == levelError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+	return errors, warnings
+}
+
+// jumpToLogIssue moves the cursor to the next (or previous) error or
+// warning after (or before) it, wrapping around, and expands the groups
+// the line is in.
+func (m *Model) jumpToLogIssue(next bool) bool {
+	if len(m.logIssues) == 0 {
+		return false
+	}
+	line := -1
+	if m.logCursor < len(m.logRows) {
+		line = m.logRows[m.logCursor].line
+	}
+	target := m.logIssues[0].line
+	if next {
+		for _, issue := range m.logIssues {
+			if issue.line > line {
+				target = issue.line
+				break
+			}
+		}
+	} else {
+		target = m.logIssues[len(m.logIssues)-1].line
+		for i := len(m.logIssues) - 1; i >= 0; i-- {
+			if m.logIssues[i].line < line {
+				target = m.logIssues[i].line
+				break
+			}
+		}
+	}
+	m.showLogLine(target)
+	return true
+}
+
+// jumpToFirstError moves the cursor to the first error of the log, if any.
+func (m *Model) jumpToFirstError() {
+	for _, issue := range m.logIssues {
+		if issue.level == levelError {
+			m.showLogLine(issue.line)
+			return
+		}
+	}
+}
+
+// showLogLine expands the groups around line and moves the cursor to it,
+// in the middle of the view.
+func (m *Model) showLogLine(line int) {
+	for gi, g := range m.logGroups {
+		if g.start < line && line <= g.end {
+			m.setGroupExpanded(gi, true)
+		}
+	}
+	m.logFollowing = false
+	m.relayoutLogs(line)
+	m.logOffset = m.logCursor - m.logVisibleLines()/2
+	m.moveLogCursor(0, false)
+}
+
+// renderIssueCounts is the error and warning count for the log header, or
+// "" when there are none.
+func renderIssueCounts(m Model) string {
+	errors, warnings := m.countLogIssues(0, math.MaxInt)
+	var parts []string
+	if errors > 0 {
+		parts = append(parts, m.styles.LogError.Render(fmt.Sprintf("✗ %d %s", errors, plural(errors, "error"))))
+	}
+	if warnings > 0 {
+		parts = append(parts, m.styles.LogWarning.Render(fmt.Sprintf("! %d %s", warnings, plural(warnings, "warning"))))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "  " + strings.Join(parts, " ")
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return noun
+	}
+	return noun + "s"
+}
@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/turkosaurus/gh-ci/internal/gh"
+	"github.com/turkosaurus/gh-ci/internal/types"
+	"github.com/turkosaurus/gh-ci/internal/ui/styles"
+)
+
+// logStep is the part of a job log written by one step, from its first
+// line up to the first line of the next step.
+type logStep struct {
+	step  types.Step
+	start int // first line
+}
+
+// splitLogSteps finds where each step of a job starts in its log, by the
+// line timestamps and the steps' start times. The API reports start times
+// in whole seconds, so a ##[group] header in the step's first second,
+// which the runner writes as a step begins, is taken as its start in
+// preference to an earlier line of the same second. Steps that were
+// skipped or have not written to the log yet have no section.
+func splitLogSteps(lines []string, steps []types.Step) []logStep {
+	var sections []logStep
+	from := 0
+	for _, step := range steps {
+		if step.StartedAt.IsZero() || step.Conclusion == types.ConclusionSkipped {
+			continue
+		}
+		start := -1
+		for i := from; i < len(lines); i++ {
+			if ts, _, ok := splitLogTimestamp(lines[i]); ok && !ts.Truncate(time.Second).Before(step.StartedAt) {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			break
+		}
+		for i := start; i < len(lines); i++ {
+			ts, _, ok := splitLogTimestamp(lines[i])
+			if ok && ts.Truncate(time.Second).After(step.StartedAt) {
+				break
+			}
+			if _, ok := logCommand(lines[i], "group"); ok {
+				start = i
+				break
+			}
+		}
+		if len(sections) == 0 {
+			start = 0 // the runner's preamble belongs to the first step
+		}
+		sections = append(sections, logStep{step: step, start: start})
+		from = start + 1
+	}
+	return sections
+}
+
+// currentLogStep returns the index into logSteps of the step under the
+// cursor, or -1.
+func (m Model) currentLogStep() int {
+	if m.logCursor >= len(m.logRows) {
+		return -1
+	}
+	line := m.logRows[m.logCursor].line
+	current := -1
+	for i, s := range m.logSteps {
+		if s.start > line {
+			break
+		}
+		current = i
+	}
+	return current
+}
+
+// jumpToLogStep moves the cursor to the first line of step i, at the top of
+// the view.
+func (m *Model) jumpToLogStep(i int) {
+	if i < 0 || i >= len(m.logSteps) {
+		return
+	}
+	m.logFollowing = false
+	m.logCursor = m.logRowOf(m.logSteps[i].start)
+	m.logOffset = m.logCursor
+	m.moveLogCursor(0, false)
+}
+
+// firstFailedLogStep returns the index into logSteps of the first step
+// that failed, or -1.
+func (m Model) firstFailedLogStep() int {
+	for i, s := range m.logSteps {
+		if s.step.Conclusion == types.ConclusionFailure {
+			return i
+		}
+	}
+	return -1
+}
+
+// stepDuration is how long a step took, or has been running.
+func stepDuration(s types.Step) string {
+	end := s.CompletedAt
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return gh.FormatDuration(int64(end.Sub(s.StartedAt).Seconds()))
+}
+
+// renderStepOutline is the line under the log header: an icon per step of
+// the job with how long it took, the one under the cursor highlighted, then
+// that step's number and name. When the durations do not fit, the icons are
+// shown alone and only the current step's duration follows its name. It is
+// empty for logs without steps.
+func renderStepOutline(m Model, w int) string {
+	if len(m.logSteps) == 0 {
+		return ""
+	}
+	current := m.currentLogStep()
+	var icons, timed []string
+	timedW := 0
+	for _, step := range m.logJobSteps {
+		style := m.styles.StatusStyle(step.Status, step.Conclusion)
+		if current >= 0 && step.Number == m.logSteps[current].step.Number {
+			style = m.styles.Selected
+		}
+		icon := styles.StatusIcon(step.Status, step.Conclusion)
+		entry := icon
+		if !step.StartedAt.IsZero() && step.Conclusion != types.ConclusionSkipped {
+			entry += " " + stepDuration(step)
+		}
+		icons = append(icons, style.Render(icon))
+		timed = append(timed, style.Render(entry))
+		timedW += lipgloss.Width(entry) + 1
+	}
+	var step types.Step
+	var label string
+	if current >= 0 {
+		step = m.logSteps[current].step
+		label = fmt.Sprintf("step %d/%d ", step.Number, len(m.logJobSteps))
+	}
+	const minName = 12 // the least of the current step's name worth showing
+	if timedW+len(label)+minName+2 <= w {
+		s := strings.Join(timed, " ")
+		if current < 0 {
+			return s
+		}
+		name := gh.TruncateString(step.Name, max(1, w-timedW-len(label)-2))
+		return s + "  " + m.styles.Dimmed.Render(label) + m.styles.Normal.Render(name)
+	}
+	s := strings.Join(icons, " ")
+	if current < 0 {
+		return s
+	}
+	dur := stepDuration(step)
+	name := gh.TruncateString(step.Name, max(1, w-2*len(icons)-len(label)-len(dur)-6))
+	return s + "  " + m.styles.Dimmed.Render(label) + m.styles.Normal.Render(name) + "  " + m.styles.Duration.Render(dur)
+}
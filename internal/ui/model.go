@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"archive/zip"
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -8,6 +11,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -36,8 +40,12 @@ const (
 )
 
 const (
-	workflowAll     = "*" // "show all workflows"
-	logViewOverhead = 4   // number of rows consumed by header, spacing, and help bar in the log view.
+	workflowAll       = "*" // "show all workflows"
+	logViewOverhead   = 4   // number of rows consumed by header, spacing, and help bar in the log view.
+	loadMoreThreshold = 10  // fetch the next page once the runs cursor is this close to the end
+	minFilteredRuns   = 10  // fewer loaded runs than this for the selected branch fetches its runs from the server
+	repoFetchWorkers  = 4   // concurrent per-repo requests in loadRuns
+	lowQuotaFraction  = 0.1 // stretch polling once remaining quota drops below this share of the limit
 )
 
 // logContextLine is one display row in the context-window log view.
@@ -67,7 +75,7 @@ func buildLogContext(lines []string, query string, ctx int) (rows []logContextLi
 	// collect matching line indices
 	var matches []int
 	for i, l := range lines {
-		if fuzzyMatch(l, query) {
+		if fuzzyMatch(stripANSI(l), query) {
 			matches = append(matches, i)
 		}
 	}
@@ -122,18 +130,68 @@ type Model struct {
 	// data
 	allRuns           []types.WorkflowRun
 	filteredRuns      []types.WorkflowRun
-	workflows         []string // flat: [workflowAll, "ci", "release", ...]
-	availableBranches []string // sorted real branch names, e.g. ["main", "feat/x"]
-	branchIdx         int      // index into availableBranches (current branch filter selection)
+	workflows         []workflowEntry // flat: [allWorkflows, ci, release, ...]
+	availableBranches []string        // sorted real branch names, e.g. ["main", "feat/x"]
+	branchIdx         int             // index into availableBranches (current branch filter selection)
 	jobs              []types.Job
+	artifacts         []types.Artifact // of the selected run
+	artifactsErr      error
+	deployments       []types.PendingDeployment // of the selected run, when waiting
 	logs              string
 	logJobName        string
+	logGroups         []logGroup   // ##[group] sections of logs
+	logGroupOpen      map[int]bool // header line → expanded, for groups toggled from their default
+	logRows           []logRow     // display rows of logs with collapsed groups folded away
+	logStripANSI      bool         // show logs without the colors of their ANSI escape sequences
+	logJobSteps       []types.Step // of the job whose logs are shown
+	logSteps          []logStep    // where each of logJobSteps starts in logs
+	logIssues         []logIssue   // error and warning lines of logs
+
+	// live tail of the shown job's logs while it is in progress
+	logFollowing bool   // polling and pinned to the bottom; paused by scrolling up
+	logJobActive bool   // the shown job had not completed when last fetched
+	logFollowSeq int    // identifies the current poll chain; stale ticks are dropped
+	logRepo      string // of the shown job
+	logJobID     int64
+
+	// artifact opened in ScreenLogs: its entry list, and whether one of the
+	// entries is being shown as logs
+	artifactName        string
+	artifactEntries     []*zip.File
+	artifactEntryCursor int
+	artifactEntryOpen   bool
 
 	// local workflow definitions discovered from .github/workflows/
 	localDefs []types.WorkflowDef
 
-	// workflow filename cache: workflow name → filename (e.g. "ci" → "ci.yaml")
-	workflowFiles map[string]string
+	// workflows registered with GitHub, per repo, for their enabled state
+	repoWorkflows map[string][]types.Workflow
+
+	// pagination: repo → next page of runs to fetch; 0 once exhausted or capped
+	runPages    map[string]int
+	loadingMore bool
+
+	// selections (a branch, or a workflow on a branch) whose runs were
+	// fetched with a server-side filter, by when; and the key and a
+	// description of the one being fetched now ("" when none)
+	runsFetched     map[string]time.Time
+	runsFetchingKey string
+	runsFetching    string
+
+	// API quota as last reported by the client
+	rateLimit gh.RateLimit
+
+	// repos whose last refresh failed; their previously loaded runs are kept
+	repoErrors map[string]error
+
+	// cancel funcs for in-flight fetches superseded by cursor movement, and
+	// the sequence numbers of the latest fetches; results of earlier ones
+	// are dropped
+	jobsCancel context.CancelFunc
+	logsCancel context.CancelFunc
+	jobsSeq    int
+	logsSeq    int
+	jobsRunID  int64 // run whose jobs are being fetched while jobsCancel is set
 
 	// dispatch confirmation state
 	dispatchConfirming bool
@@ -141,11 +199,19 @@ type Model struct {
 	dispatchFile       string
 	dispatchRef        string
 
+	// dispatch input form (only when the workflow declares inputs)
+	dispatchInputs []types.DispatchInput
+	dispatchValues []string // one per input, as typed
+	dispatchField  int      // focused input
+	dispatchEditor textinput.Model
+	dispatchErr    string // validation error for the last submit
+
 	// navigation
 	workflowCursor int
 	cursor         int
-	jobCursor      int
+	jobCursor      int // indexes jobs, then continues through artifacts
 	logOffset      int
+	logCursor      int // indexes logRows; search results are scrolled without one
 
 	// log search
 	logQuery        string
@@ -170,27 +236,81 @@ type Model struct {
 	defaultBranch string // from config; repo primary branch (e.g. "main")
 	localBranch   string // current git checkout; used for local def scoping and local-only dispatch
 
+	// pending deployment review: pick approve/reject, then enter a comment
+	reviewConfirming bool
+	reviewRepo       string
+	reviewRunID      int64
+	reviewEnvs       []types.Environment // environments the user can review
+	reviewDecided    bool                // approve/reject chosen; editing the comment
+	reviewApprove    bool
+	reviewComment    textinput.Model
+
+	// fork pull request run approval
+	approveConfirming bool
+	approveRun        types.WorkflowRun
+
+	// workflow enable/disable confirmation
+	toggleConfirming bool
+	toggleRepo       string
+	toggleWorkflow   types.Workflow
+
+	// run deletion: confirm by typing the run number (or the count of runs),
+	// then delete the queued runs one at a time
+	deleteConfirming bool
+	deleteRuns       []types.WorkflowRun // awaiting confirmation
+	deleteLogsOnly   bool
+	deleteInput      textinput.Model
+	deleteErr        string
+	deleteQueue      []types.WorkflowRun // being deleted; nil when idle
+	deleteDone       int                 // runs of deleteQueue finished
+	deleteFailed     []deleteFailure
+
 	// rerun confirmation
-	confirming  bool
-	confirmRepo string
-	confirmID   int64
+	confirming     bool
+	confirmRepo    string
+	confirmID      int64
+	confirmJobID   int64 // job under the cursor when confirming from panelDetail; 0 otherwise
+	confirmJobName string
+	confirmFailed  bool // run concluded with failure, so "failed jobs only" is offered
 }
 
 type (
 	runsLoadedMsg struct {
-		runs []types.WorkflowRun
-		err  error
+		runs      []types.WorkflowRun
+		nextPages map[string]int   // repo → next page after the first
+		repoErrs  map[string]error // repos whose runs failed to load
+	}
+	moreRunsLoadedMsg struct {
+		repo     string
+		runs     []types.WorkflowRun
+		nextPage int
+		err      error
+	}
+	filteredRunsLoadedMsg struct {
+		key   string // runsFetched key of the selection
+		label string // describes the selection, e.g. "release on main"
+		runs  []types.WorkflowRun
+		err   error // first repo to fail; runs from the others are kept
 	}
 	jobsLoadedMsg struct {
-		jobs []types.Job
-		err  error
+		seq          int
+		runID        int64
+		jobs         []types.Job
+		artifacts    []types.Artifact
+		artifactsErr error // artifacts are optional; jobs still load without them
+		deployments  []types.PendingDeployment
+		err          error
 	}
 	logsLoadedMsg struct {
+		seq     int
+		repo    string
 		logs    string
+		jobID   int64
 		jobName string
 		err     error
 	}
 	actionResultMsg struct {
+		action  string // one of the action* constants, for error hints
 		message string
 		err     error
 	}
@@ -198,6 +318,40 @@ type (
 		message string
 		err     error
 	}
+	downloadResultMsg struct {
+		path string
+		err  error
+	}
+	artifactLoadedMsg struct {
+		artifactID int64
+		name       string
+		entries    []*zip.File
+		err        error
+	}
+	workflowsLoadedMsg struct {
+		workflows map[string][]types.Workflow // repo → workflows; repos that failed are absent
+	}
+	workflowToggledMsg struct {
+		repo       string
+		workflowID int64
+		name       string
+		state      string // the workflow's new state
+		err        error
+	}
+	runDeletedMsg struct {
+		runID int64
+		err   error
+	}
+	logFollowTickMsg struct {
+		seq int // logFollowSeq when the tick was scheduled
+	}
+	logsFollowedMsg struct {
+		seq     int
+		job     types.Job // the followed job as re-fetched, for its status
+		logs    string
+		err     error
+		missing bool // the job no longer exists
+	}
 	tickMsg     time.Time
 	clearMsgMsg struct{}
 )
@@ -231,7 +385,8 @@ func scanLocalWorkflows() ([]types.WorkflowDef, error) {
 				return nil, fmt.Errorf("read workflow file %q: %w", path, err)
 			}
 			var wf struct {
-				Name string `yaml:"name"`
+				Name string    `yaml:"name"`
+				On   yaml.Node `yaml:"on"`
 			}
 			_ = yaml.Unmarshal(data, &wf)
 			name := wf.Name
@@ -240,8 +395,12 @@ func scanLocalWorkflows() ([]types.WorkflowDef, error) {
 				name = strings.TrimSuffix(filepath.Base(path), ext)
 			}
 			file := filepath.Base(path)
-			defs = append(defs, types.WorkflowDef{Name: name, File: file})
-			slog.Debug("discovered local workflow definition", "name", name, "file", file)
+			inputs, err := parseDispatchInputs(&wf.On)
+			if err != nil {
+				slog.Debug("ignoring malformed workflow_dispatch inputs", "file", file, "err", err)
+			}
+			defs = append(defs, types.WorkflowDef{Name: name, File: file, Inputs: inputs})
+			slog.Debug("discovered local workflow definition", "name", name, "file", file, "inputs", len(inputs))
 		}
 	}
 	if len(defs) == 0 {
@@ -250,6 +409,46 @@ func scanLocalWorkflows() ([]types.WorkflowDef, error) {
 	return defs, nil
 }
 
+// newClient builds the gh.Client backend selected by cfg.Client.
+func newClient(cfg *config.Config) (gh.Client, error) {
+	switch cfg.Client {
+	case config.ClientCLI:
+		return gh.NewClient(), nil
+	case config.ClientHTTP, "":
+		// github.com needs a token up front unless every repo is on an
+		// enterprise host; those tokens are resolved on first use.
+		var token string
+		if usesDefaultHost(cfg.Repos) {
+			var err error
+			if token, err = gh.ResolveToken(); err != nil {
+				return nil, fmt.Errorf("resolve token (set GH_TOKEN or run `gh auth login`): %w", err)
+			}
+		}
+		client := gh.NewHTTPClient(cfg.APIURL, token)
+		client.SetRetryPolicy(gh.RetryPolicy{
+			MaxAttempts: cfg.Retry.MaxAttempts,
+			BaseDelay:   time.Duration(cfg.Retry.BaseDelayMs) * time.Millisecond,
+			MaxDelay:    time.Duration(cfg.Retry.MaxDelayMs) * time.Millisecond,
+		})
+		return client, nil
+	default:
+		return nil, fmt.Errorf("unknown client %q: want %q or %q", cfg.Client, config.ClientHTTP, config.ClientCLI)
+	}
+}
+
+// usesDefaultHost reports whether any repo is on gh.DefaultHost.
+func usesDefaultHost(repos []string) bool {
+	if len(repos) == 0 {
+		return true
+	}
+	for _, repo := range repos {
+		if host, _ := gh.SplitHost(repo); host == gh.DefaultHost {
+			return true
+		}
+	}
+	return false
+}
+
 func NewModel(cfg *config.Config) (Model, error) {
 	ti := textinput.New()
 	ti.Placeholder = "search logs..."
@@ -257,75 +456,392 @@ func NewModel(cfg *config.Config) (Model, error) {
 	bi := textinput.New()
 	bi.Placeholder = "filter branches..."
 	bi.CharLimit = 100
+	di := textinput.New()
+	di.Prompt = ""
+	di.CharLimit = 500
+	rc := textinput.New()
+	rc.Placeholder = "comment (optional)"
+	rc.CharLimit = 500
+	dl := textinput.New()
+	dl.Prompt = ""
+	dl.CharLimit = 10
 	workflowsLocal, err := scanLocalWorkflows()
 	if err != nil {
 		return Model{}, fmt.Errorf("scan local workflows: %w", err)
 	}
+	client, err := newClient(cfg)
+	if err != nil {
+		return Model{}, fmt.Errorf("create api client: %w", err)
+	}
 	return Model{
 		config:         cfg,
-		client:         gh.NewClient(),
+		client:         client,
 		styles:         styles.DefaultStyles(),
 		keys:           keys.DefaultKeyMap(),
 		textInput:      ti,
 		branchInput:    bi,
+		dispatchEditor: di,
+		reviewComment:  rc,
+		deleteInput:    dl,
 		loading:        true,
 		workflowCursor: 1, // start on workflowAll (0=branch, 1=workflows[0])
 		localDefs:      workflowsLocal,
-		workflowFiles:  make(map[string]string),
+		repoWorkflows:  make(map[string][]types.Workflow),
+		runPages:       make(map[string]int),
 		defaultBranch:  cfg.DefaultPrimaryBranch,
 		localBranch:    currentGitBranch(),
 	}, nil
 }
 
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(m.loadRuns(), m.tick())
+	return tea.Batch(m.loadRuns(), m.loadWorkflows(), m.tick())
 }
 
 func (m Model) tick() tea.Cmd {
-	return tea.Tick(time.Duration(m.config.RefreshInterval)*time.Second, func(t time.Time) tea.Msg {
+	return tea.Tick(m.pollInterval(time.Now()), func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
+// pollInterval returns the delay before the next automatic refresh given the
+// last known quota.
+func (m Model) pollInterval(now time.Time) time.Duration {
+	base := time.Duration(m.config.RefreshInterval) * time.Second
+	perPoll := len(m.config.Repos) + 2 // one list per repo plus the selected run's jobs and artifacts
+	return pollInterval(base, m.rateLimit, perPoll, now)
+}
+
+// pollInterval is the configured interval while quota is healthy. Once the
+// remaining quota falls below lowQuotaFraction of the limit the interval is
+// stretched so the remaining requests last until the reset, and when too
+// little is left for another poll it waits for the reset itself.
+func pollInterval(base time.Duration, rl gh.RateLimit, perPoll int, now time.Time) time.Duration {
+	if !rl.Known() || !now.Before(rl.Reset) {
+		return base
+	}
+	untilReset := rl.Reset.Sub(now)
+	if rl.Remaining < perPoll {
+		return untilReset + time.Second
+	}
+	if float64(rl.Remaining) >= float64(rl.Limit)*lowQuotaFraction {
+		return base
+	}
+	stretched := untilReset / time.Duration(rl.Remaining/perPoll)
+	if stretched < base {
+		return base
+	}
+	return stretched
+}
+
+// runsPerPage is the page size used when listing runs, bounded by the
+// configured per-repo maximum.
+func (m Model) runsPerPage() int {
+	return min(gh.MaxPerPage, m.config.MaxRunsPerRepo)
+}
+
+// requestContext returns a context bounded by the configured per-call timeout.
+func (m Model) requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), time.Duration(m.config.RequestTimeout)*time.Second)
+}
+
+// loadRuns fetches the first page of runs for every configured repo, using up
+// to repoFetchWorkers concurrent requests. A failing repo does not abort the
+// others; its error is reported in runsLoadedMsg.repoErrs.
 func (m Model) loadRuns() tea.Cmd {
 	return func() tea.Msg {
-		var all []types.WorkflowRun
-		for _, repo := range m.config.Repos {
-			runs, err := m.client.ListWorkflowRuns(repo, 100)
+		type result struct {
+			page gh.RunsPage
+			err  error
+		}
+		repos := m.config.Repos
+		results := make([]result, len(repos))
+		next := make(chan int)
+		var wg sync.WaitGroup
+		for range min(repoFetchWorkers, len(repos)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range next {
+					ctx, cancel := m.requestContext()
+					page, err := m.client.ListWorkflowRuns(ctx, repos[i], gh.RunListOptions{PerPage: m.runsPerPage()})
+					cancel()
+					results[i] = result{page: page, err: err}
+				}
+			}()
+		}
+		for i := range repos {
+			next <- i
+		}
+		close(next)
+		wg.Wait()
+
+		msg := runsLoadedMsg{nextPages: make(map[string]int), repoErrs: make(map[string]error)}
+		for i, repo := range repos {
+			res := results[i]
+			if res.err != nil {
+				slog.Debug("failed to load runs", "repo", repo, "err", res.err)
+				msg.repoErrs[repo] = res.err
+				continue
+			}
+			msg.runs = append(msg.runs, res.page.Runs...)
+			msg.nextPages[repo] = res.page.NextPage
+			if len(res.page.Runs) >= m.config.MaxRunsPerRepo {
+				msg.nextPages[repo] = 0
+			}
+		}
+		return msg
+	}
+}
+
+func (m Model) loadMoreRuns(repo string, page int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		res, err := m.client.ListWorkflowRuns(ctx, repo, gh.RunListOptions{PerPage: m.runsPerPage(), Page: page})
+		if err != nil {
+			return moreRunsLoadedMsg{repo: repo, err: err}
+		}
+		return moreRunsLoadedMsg{repo: repo, runs: res.Runs, nextPage: res.NextPage}
+	}
+}
+
+// maybeLoadMoreRuns fetches the next page for the first repo that still has
+// one once the runs cursor nears the end of filteredRuns.
+func (m *Model) maybeLoadMoreRuns() tea.Cmd {
+	if m.loadingMore || len(m.filteredRuns)-m.cursor > loadMoreThreshold {
+		return nil
+	}
+	for _, repo := range m.config.Repos {
+		if page := m.runPages[repo]; page > 0 {
+			m.loadingMore = true
+			slog.Debug("loading more runs", "repo", repo, "page", page)
+			return m.loadMoreRuns(repo, page)
+		}
+	}
+	return nil
+}
+
+// fetchFilteredRuns requests runs for the current selection from the
+// server: a selected workflow's runs on the selected branch through the
+// workflow's own listing, so rarely-run workflows show their history, or
+// otherwise the branch's runs when fewer than minFilteredRuns are loaded,
+// e.g. for a quiet branch. A fetched selection is refreshed at most once per
+// refresh interval, so the statuses of runs older than the first page stay
+// current; a failed fetch is tried again on the next refresh.
+func (m *Model) fetchFilteredRuns() tea.Cmd {
+	if m.branchIdx >= len(m.availableBranches) {
+		return nil
+	}
+	branch := m.availableBranches[m.branchIdx]
+	opts := gh.RunListOptions{PerPage: m.runsPerPage(), Filter: gh.RunFilter{Branch: branch}}
+	client := m.client
+	repos := m.config.Repos
+
+	msg := filteredRunsLoadedMsg{key: branch, label: branch}
+	list := func(ctx context.Context, repo string) (gh.RunsPage, error) {
+		return client.ListWorkflowRuns(ctx, repo, opts)
+	}
+	if wf := m.selectedWorkflow(); wf.specific() && wf.file != "" {
+		msg.key = wf.repo + "/" + wf.file + "@" + branch
+		msg.label = wf.name + " on " + branch
+		if wf.repo != "" {
+			repos = []string{wf.repo}
+		}
+		list = func(ctx context.Context, repo string) (gh.RunsPage, error) {
+			return client.ListWorkflowRunsForWorkflow(ctx, repo, wf.file, opts)
+		}
+	} else if _, ok := m.runsFetched[msg.key]; !ok && len(m.filteredRuns) >= minFilteredRuns {
+		return nil
+	}
+	if m.runsFetchingKey == msg.key {
+		return nil
+	}
+	if fetched, ok := m.runsFetched[msg.key]; ok && time.Since(fetched) < time.Duration(m.config.RefreshInterval)*time.Second {
+		return nil
+	}
+	m.runsFetchingKey = msg.key
+	m.runsFetching = msg.label
+	slog.Debug("fetching filtered runs", "selection", msg.key)
+
+	return func() tea.Msg {
+		for _, repo := range repos {
+			ctx, cancel := m.requestContext()
+			page, err := list(ctx, repo)
+			cancel()
+			if gh.IsNotFound(err) && len(repos) > 1 {
+				// a local workflow file need not exist in every configured repo
+				continue
+			}
 			if err != nil {
-				return runsLoadedMsg{err: err}
+				slog.Debug("failed to load filtered runs", "repo", repo, "selection", msg.key, "err", err)
+				if msg.err == nil {
+					msg.err = fmt.Errorf("%s: %w", repo, err)
+				}
+				continue
 			}
-			all = append(all, runs...)
+			msg.runs = append(msg.runs, page.Runs...)
 		}
-		return runsLoadedMsg{runs: all}
+		return msg
 	}
 }
 
-func (m Model) loadJobs(repo string, runID int64) tea.Cmd {
+// mergeRuns overlays a freshly fetched first page of runs onto the runs
+// already loaded, keeping older runs that came from later pages.
+func mergeRuns(existing, fresh []types.WorkflowRun) []types.WorkflowRun {
+	seen := make(map[int64]bool, len(fresh))
+	oldest := make(map[string]time.Time) // repo → oldest CreatedAt in fresh
+	for _, r := range fresh {
+		seen[r.ID] = true
+		repo := r.Repository.FullName
+		if t, ok := oldest[repo]; !ok || r.CreatedAt.Before(t) {
+			oldest[repo] = r.CreatedAt
+		}
+	}
+	merged := append([]types.WorkflowRun(nil), fresh...)
+	for _, r := range existing {
+		if seen[r.ID] {
+			continue
+		}
+		if t, ok := oldest[r.Repository.FullName]; ok && r.CreatedAt.Before(t) {
+			merged = append(merged, r)
+		}
+	}
+	return merged
+}
+
+// updateRuns replaces the runs already loaded with their refetched copies,
+// and appends those not loaded yet.
+func updateRuns(existing, fresh []types.WorkflowRun) []types.WorkflowRun {
+	index := make(map[int64]int, len(existing))
+	for i, r := range existing {
+		index[r.ID] = i
+	}
+	for _, r := range fresh {
+		if i, ok := index[r.ID]; ok {
+			existing[i] = r
+		} else {
+			existing = append(existing, r)
+		}
+	}
+	return existing
+}
+
+// appendRuns appends runs from a later page, skipping any already present
+// (pages shift as new runs are created between requests).
+func appendRuns(existing, more []types.WorkflowRun) []types.WorkflowRun {
+	seen := make(map[int64]bool, len(existing))
+	for _, r := range existing {
+		seen[r.ID] = true
+	}
+	for _, r := range more {
+		if !seen[r.ID] {
+			existing = append(existing, r)
+		}
+	}
+	return existing
+}
+
+// hasMoreRuns reports whether any repo has further pages of runs to fetch.
+func (m Model) hasMoreRuns() bool {
+	for _, page := range m.runPages {
+		if page > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// repoRunCount returns how many loaded runs belong to repo.
+func (m Model) repoRunCount(repo string) int {
+	n := 0
+	for _, r := range m.allRuns {
+		if r.Repository.FullName == repo {
+			n++
+		}
+	}
+	return n
+}
+
+// loadJobs fetches jobs, artifacts and, for a waiting run, pending
+// deployments for run, cancelling any jobs fetch still in flight.
+func (m *Model) loadJobs(run types.WorkflowRun) tea.Cmd {
+	m.cancelJobs()
+	ctx, cancel := m.requestContext()
+	m.jobsCancel = cancel
+	m.jobsRunID = run.ID
+	seq := m.jobsSeq
+	client := m.client
+	repo, runID := run.Repository.FullName, run.ID
 	return func() tea.Msg {
-		jobs, err := m.client.GetJobs(repo, runID)
+		defer cancel()
+		jobs, err := client.GetJobs(ctx, repo, runID)
 		if err != nil {
-			return jobsLoadedMsg{err: err}
+			return jobsLoadedMsg{seq: seq, runID: runID, err: err}
+		}
+		msg := jobsLoadedMsg{seq: seq, runID: runID, jobs: jobs}
+		msg.artifacts, msg.artifactsErr = client.ListArtifacts(ctx, repo, runID)
+		if run.Status == types.RunStatusWaiting {
+			if msg.deployments, err = client.ListPendingDeployments(ctx, repo, runID); err != nil {
+				slog.Debug("list pending deployments", "repo", repo, "run", runID, "err", err)
+			}
 		}
-		return jobsLoadedMsg{jobs: jobs}
+		return msg
 	}
 }
 
-func (m Model) loadLogs(repo string, jobID int64, jobName string) tea.Cmd {
+// refreshJobs reloads the selected run's jobs on a refresh, unless a fetch
+// for the run is still in flight: cancelling it for a new one would starve
+// fetches slower than the refresh interval.
+func (m *Model) refreshJobs(run types.WorkflowRun) tea.Cmd {
+	if m.jobsCancel != nil && m.jobsRunID == run.ID {
+		return nil
+	}
+	return m.loadJobs(run)
+}
+
+// loadLogs fetches logs for jobID, cancelling any logs fetch still in flight.
+func (m *Model) loadLogs(repo string, jobID int64, jobName string) tea.Cmd {
+	m.cancelLogs()
+	ctx, cancel := m.requestContext()
+	m.logsCancel = cancel
+	seq := m.logsSeq
+	client := m.client
 	return func() tea.Msg {
-		logs, err := m.client.GetJobLogs(repo, jobID)
+		defer cancel()
+		logs, err := client.GetJobLogs(ctx, repo, jobID)
 		if err != nil {
-			return logsLoadedMsg{err: err, jobName: jobName}
+			return logsLoadedMsg{seq: seq, err: err, repo: repo, jobID: jobID, jobName: jobName}
 		}
-		return logsLoadedMsg{logs: logs, jobName: jobName}
+		return logsLoadedMsg{seq: seq, logs: logs, repo: repo, jobID: jobID, jobName: jobName}
+	}
+}
+
+// cancelJobs cancels the jobs fetch in flight, if any, and makes the result
+// of every earlier fetch stale.
+func (m *Model) cancelJobs() {
+	m.jobsSeq++
+	if m.jobsCancel != nil {
+		m.jobsCancel()
+		m.jobsCancel = nil
+	}
+}
+
+// cancelLogs is cancelJobs for logs fetches.
+func (m *Model) cancelLogs() {
+	m.logsSeq++
+	if m.logsCancel != nil {
+		m.logsCancel()
+		m.logsCancel = nil
 	}
 }
 
 func (m Model) rerunWorkflow(repo string, runID int64, debug bool) tea.Cmd {
 	return func() tea.Msg {
-		err := m.client.RerunWorkflow(repo, runID, debug)
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		err := m.client.RerunWorkflow(ctx, repo, runID, debug)
 		if err != nil {
-			return actionResultMsg{err: err}
+			return actionResultMsg{action: actionRerun, err: err}
 		}
 		if debug {
 			return actionResultMsg{message: "re-run triggered (debug logging enabled)"}
@@ -334,19 +850,66 @@ func (m Model) rerunWorkflow(repo string, runID int64, debug bool) tea.Cmd {
 	}
 }
 
+func (m Model) rerunJob(repo string, jobID int64, debug bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		err := m.client.RerunJob(ctx, repo, jobID, debug)
+		if err != nil {
+			return actionResultMsg{action: actionRerun, err: err}
+		}
+		if debug {
+			return actionResultMsg{message: "job re-run triggered (debug logging enabled)"}
+		}
+		return actionResultMsg{message: "job re-run triggered"}
+	}
+}
+
+func (m Model) rerunFailedJobs(repo string, runID int64, debug bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		err := m.client.RerunFailedJobs(ctx, repo, runID, debug)
+		if err != nil {
+			return actionResultMsg{action: actionRerun, err: err}
+		}
+		if debug {
+			return actionResultMsg{message: "failed jobs re-run triggered (debug logging enabled)"}
+		}
+		return actionResultMsg{message: "failed jobs re-run triggered"}
+	}
+}
+
+// failedJobCount returns how many of jobs a "re-run failed jobs" would retry,
+// not counting dependents that are re-run alongside them.
+func failedJobCount(jobs []types.Job) int {
+	n := 0
+	for _, j := range jobs {
+		switch j.Conclusion {
+		case types.ConclusionFailure, types.ConclusionCancelled, types.ConclusionTimedOut:
+			n++
+		}
+	}
+	return n
+}
+
 func (m Model) cancelWorkflow(repo string, runID int64) tea.Cmd {
 	return func() tea.Msg {
-		err := m.client.CancelWorkflow(repo, runID)
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		err := m.client.CancelWorkflow(ctx, repo, runID)
 		if err != nil {
-			return actionResultMsg{err: err}
+			return actionResultMsg{action: actionCancel, err: err}
 		}
 		return actionResultMsg{message: "workflow cancelled"}
 	}
 }
 
-func (m Model) runDispatch(repo, file, ref string) tea.Cmd {
+func (m Model) runDispatch(repo, file, ref string, inputs map[string]string) tea.Cmd {
 	return func() tea.Msg {
-		err := m.client.DispatchWorkflow(repo, file, ref)
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		err := m.client.DispatchWorkflow(ctx, repo, file, ref, inputs)
 		if err != nil {
 			return dispatchResultMsg{err: err}
 		}
@@ -354,31 +917,72 @@ func (m Model) runDispatch(repo, file, ref string) tea.Cmd {
 	}
 }
 
+// Actions named in error messages; errorHint tailors its advice to them.
+const (
+	actionLoadRuns = "loading runs"
+	actionLoadMore = "loading more runs"
+	actionLoadJobs = "loading jobs"
+	actionLoadLogs = "loading logs"
+	actionRerun    = "re-running"
+	actionCancel   = "cancelling"
+	actionDispatch = "dispatching"
+	actionDownload = "downloading artifact"
+	actionReview   = "reviewing deployments"
+	actionApprove  = "approving run"
+	actionToggle   = "changing workflow state"
+	actionDelete   = "deleting"
+)
+
+// errorMessage formats a failed action for the status bar, with a hint
+// when the API error is one we can explain.
+func errorMessage(action string, err error) string {
+	msg := "error " + action + ": " + err.Error()
+	if hint := errorHint(action, err); hint != "" {
+		msg += " (hint: " + hint + ")"
+	}
+	return msg
+}
+
+// errorHint returns advice for a typed API error, or "" when there is none.
+func errorHint(action string, err error) string {
+	switch {
+	case gh.IsRateLimited(err):
+		return "API rate limit exceeded; polling slows down until the quota resets"
+	case gh.IsGone(err) && action == actionLoadLogs:
+		return "logs for this job have expired or been deleted"
+	case gh.IsGone(err) && action == actionDownload:
+		return "artifact has expired or been deleted"
+	case gh.IsGone(err):
+		return "this resource no longer exists"
+	case gh.IsNotFound(err) && action == actionDispatch:
+		return "workflow file must exist on the default branch to be dispatched"
+	case gh.IsNotFound(err) && action == actionLoadLogs:
+		return "logs are not available until the job has started"
+	case gh.IsNotFound(err):
+		return "repository or run not found, or the token cannot see it"
+	case gh.IsForbidden(err) && action == actionReview:
+		return "only required reviewers of the environment can approve or reject it"
+	case gh.IsForbidden(err) && (action == actionRerun || action == actionCancel || action == actionDispatch || action == actionApprove || action == actionToggle || action == actionDelete):
+		return "token lacks permission; it needs the actions:write scope on this repository"
+	case gh.IsForbidden(err):
+		return "token lacks access to this repository"
+	}
+	return ""
+}
+
 func clearMsg() tea.Cmd {
 	return tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
 		return clearMsgMsg{}
 	})
 }
 
-// deriveWorkflows collects unique workflow names (sorted, prefixed with workflowAll)
-// and unique branch names (sorted, no sentinel — all entries are real branches).
-// localDefs are merged in so workflows with no runs still appear.
-func deriveWorkflows(runs []types.WorkflowRun, localDefs []types.WorkflowDef) (workflows []string, branches []string) {
-	wfSeen := map[string]bool{}
+// deriveBranches collects unique branch names (sorted, no sentinel — all
+// entries are real branches).
+func deriveBranches(runs []types.WorkflowRun) (branches []string) {
 	brSeen := map[string]bool{}
 	for _, r := range runs {
-		wfSeen[r.Name] = true
 		brSeen[r.HeadBranch] = true
 	}
-	for _, def := range localDefs {
-		wfSeen[def.Name] = true
-	}
-	for w := range wfSeen {
-		workflows = append(workflows, w)
-	}
-	sort.Strings(workflows)
-	workflows = append([]string{workflowAll}, workflows...)
-
 	for b := range brSeen {
 		branches = append(branches, b)
 	}
@@ -404,7 +1008,48 @@ func (m Model) selectedBranch() string {
 	return m.defaultBranch
 }
 
-func (m *Model) applyFilter() {
+// refreshBranches re-derives availableBranches from allRuns, preserving the
+// selected branch by name.
+func (m *Model) refreshBranches() {
+	// Preserve selected branch by name; on first load start on local checkout
+	prevBranch := ""
+	if m.availableBranches == nil {
+		prevBranch = m.localBranch
+	} else if m.branchIdx < len(m.availableBranches) {
+		prevBranch = m.availableBranches[m.branchIdx]
+	}
+	m.availableBranches = deriveBranches(m.allRuns)
+	// ensure both the configured primary branch and the local checkout are
+	// always present, even when they have no runs yet
+	for _, branch := range []string{m.defaultBranch, m.localBranch} {
+		if branch == "" {
+			continue
+		}
+		found := false
+		for _, b := range m.availableBranches {
+			if b == branch {
+				found = true
+				break
+			}
+		}
+		if !found {
+			m.availableBranches = append(m.availableBranches, branch)
+			sort.Strings(m.availableBranches)
+		}
+	}
+	m.branchIdx = 0
+	for i, b := range m.availableBranches {
+		if b == prevBranch {
+			m.branchIdx = i
+			break
+		}
+	}
+}
+
+// applyFilter narrows allRuns to the selected branch and workflow. The
+// returned command fetches the branch's runs from the server when too few
+// are loaded.
+func (m *Model) applyFilter() tea.Cmd {
 	runs := m.allRuns
 
 	// Apply branch filter — always active since there is no "all branches" option.
@@ -422,36 +1067,20 @@ func (m *Model) applyFilter() {
 
 	// Re-derive workflow list from branch-filtered runs, plus any local def that
 	// has never run anywhere (so it can be dispatched from any branch).
-	wfSeen := map[string]bool{}
-	for _, r := range branchRuns {
-		wfSeen[r.Name] = true
-	}
 	selectedBranch := ""
 	if m.branchIdx < len(m.availableBranches) {
 		selectedBranch = m.availableBranches[m.branchIdx]
 	}
+	var localDefs []types.WorkflowDef
 	if selectedBranch == m.localBranch && m.localBranch != "" {
-		hasRunsAnywhere := map[string]bool{}
-		for _, r := range m.allRuns {
-			hasRunsAnywhere[r.Name] = true
-		}
-		for _, def := range m.localDefs {
-			if !hasRunsAnywhere[def.Name] {
-				wfSeen[def.Name] = true
-			}
-		}
+		localDefs = m.localDefs
 	}
-	var workflows []string
-	for w := range wfSeen {
-		workflows = append(workflows, w)
-	}
-	sort.Strings(workflows)
-	workflows = append([]string{workflowAll}, workflows...)
-	// Preserve workflowCursor by name across re-derives.
-	if prevWf := m.selectedWorkflow(); prevWf != "" {
+	workflows := deriveWorkflows(branchRuns, m.allRuns, localDefs)
+	// Preserve workflowCursor by workflow across re-derives.
+	if prevWf := m.selectedWorkflow(); prevWf.name != "" {
 		m.workflowCursor = 1 // default to workflowAll if not found
 		for i, w := range workflows {
-			if w == prevWf {
+			if w.same(prevWf) {
 				m.workflowCursor = i + 1
 				break
 			}
@@ -463,20 +1092,21 @@ func (m *Model) applyFilter() {
 
 	// Apply workflow filter.
 	runs = branchRuns
-	if wfName := m.selectedWorkflow(); wfName != "" && wfName != workflowAll {
-		var wf []types.WorkflowRun
+	if wf := m.selectedWorkflow(); wf.specific() {
+		var wfRuns []types.WorkflowRun
 		for _, r := range runs {
-			if r.Name == wfName {
-				wf = append(wf, r)
+			if wf.matches(r) {
+				wfRuns = append(wfRuns, r)
 			}
 		}
-		runs = wf
+		runs = wfRuns
 	}
 
 	m.filteredRuns = runs
 	if m.cursor >= len(m.filteredRuns) {
 		m.cursor = max(0, len(m.filteredRuns)-1)
 	}
+	return m.fetchFilteredRuns()
 }
 
 func (m Model) selectedRun() *types.WorkflowRun {
@@ -486,13 +1116,14 @@ func (m Model) selectedRun() *types.WorkflowRun {
 	return nil
 }
 
-// selectedWorkflow returns the workflow name at the current workflow cursor,
-// or "" when the branch row (cursor 0) or an out-of-range position is selected.
-func (m Model) selectedWorkflow() string {
+// selectedWorkflow returns the workflow at the current workflow cursor, or
+// the zero entry when the branch row (cursor 0) or an out-of-range position
+// is selected.
+func (m Model) selectedWorkflow() workflowEntry {
 	if m.workflowCursor > 0 && m.workflowCursor <= len(m.workflows) {
 		return m.workflows[m.workflowCursor-1]
 	}
-	return ""
+	return workflowEntry{}
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -510,6 +1141,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.dispatchConfirming {
 			return m.handleDispatchConfirm(msg)
 		}
+		if m.reviewConfirming {
+			return m.handleReviewConfirm(msg)
+		}
+		if m.approveConfirming {
+			return m.handleApproveConfirm(msg)
+		}
+		if m.toggleConfirming {
+			return m.handleToggleConfirm(msg)
+		}
+		if m.deleteConfirming {
+			return m.handleDeleteConfirm(msg)
+		}
 		if m.confirming {
 			return m.handleConfirm(msg)
 		}
@@ -522,103 +1165,221 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case runsLoadedMsg:
 		m.loading = false
-		if msg.err != nil {
-			m.message = "error: " + msg.err.Error()
-		} else {
-			m.allRuns = msg.runs
-			// Preserve selected branch by name; on first load start on local checkout
-			prevBranch := ""
-			if m.availableBranches == nil {
-				prevBranch = m.localBranch
-			} else if m.branchIdx < len(m.availableBranches) {
-				prevBranch = m.availableBranches[m.branchIdx]
-			}
-			_, m.availableBranches = deriveWorkflows(m.allRuns, m.localDefs)
-			// ensure both the configured primary branch and the local checkout are
-			// always present, even when they have no runs yet
-			for _, branch := range []string{m.defaultBranch, m.localBranch} {
-				if branch == "" {
-					continue
-				}
-				found := false
-				for _, b := range m.availableBranches {
-					if b == branch {
-						found = true
-						break
-					}
-				}
-				if !found {
-					m.availableBranches = append(m.availableBranches, branch)
-					sort.Strings(m.availableBranches)
-				}
-			}
-			m.branchIdx = 0
-			for i, b := range m.availableBranches {
-				if b == prevBranch {
-					m.branchIdx = i
-					break
-				}
+		m.rateLimit = m.client.RateLimit()
+		m.repoErrors = msg.repoErrs
+		var failedRepo string
+		for _, repo := range m.config.Repos {
+			if _, ok := msg.repoErrs[repo]; ok {
+				failedRepo = repo
+				break
 			}
-			m.applyFilter()
-			if run := m.selectedRun(); run != nil {
-				cmds = append(cmds, m.loadJobs(run.Repository.FullName, run.ID))
+		}
+		if err := msg.repoErrs[failedRepo]; err != nil {
+			if (gh.IsRateLimited(err) || m.rateLimit.Exhausted(time.Now())) && !m.rateLimit.Reset.IsZero() {
+				m.message = "rate limit exceeded; polling paused until " + m.rateLimit.Reset.Format("15:04:05")
+			} else {
+				m.message = errorMessage(actionLoadRuns, fmt.Errorf("%s: %w", failedRepo, err))
 			}
-			// populate workflowFiles (name → filename) from path field in run response
+		}
+		if len(msg.repoErrs) < len(m.config.Repos) {
+			// keep what we already had for repos that failed this time
+			fresh := msg.runs
 			for _, r := range m.allRuns {
-				if r.Path == "" {
-					continue
-				}
-				if _, ok := m.workflowFiles[r.Name]; ok {
-					continue
+				if _, failed := msg.repoErrs[r.Repository.FullName]; failed {
+					fresh = append(fresh, r)
 				}
-				parts := strings.Split(r.Path, "/")
-				m.workflowFiles[r.Name] = parts[len(parts)-1]
 			}
-			// also populate from local defs (covers workflows with no runs)
-			for _, def := range m.localDefs {
-				if _, ok := m.workflowFiles[def.Name]; !ok {
-					m.workflowFiles[def.Name] = def.File
+			m.allRuns = mergeRuns(m.allRuns, fresh)
+			for repo, next := range msg.nextPages {
+				// keep paging progress across refreshes; only seed unseen repos
+				if _, ok := m.runPages[repo]; !ok {
+					m.runPages[repo] = next
 				}
 			}
+			m.refreshBranches()
+			cmd := m.applyFilter()
+			cmds = append(cmds, cmd)
+			if run := m.selectedRun(); run != nil {
+				cmd := m.refreshJobs(*run)
+				cmds = append(cmds, cmd)
+			}
+		}
+
+	case moreRunsLoadedMsg:
+		m.loadingMore = false
+		if msg.err != nil {
+			m.message = errorMessage(actionLoadMore, msg.err)
+			m.runPages[msg.repo] = 0
+			cmds = append(cmds, clearMsg())
+			break
+		}
+		m.allRuns = appendRuns(m.allRuns, msg.runs)
+		m.runPages[msg.repo] = msg.nextPage
+		if m.repoRunCount(msg.repo) >= m.config.MaxRunsPerRepo {
+			m.runPages[msg.repo] = 0
+		}
+		m.refreshBranches()
+		cmd := m.applyFilter()
+		cmds = append(cmds, cmd)
+		if m.activePanel == panelRuns {
+			cmd := m.maybeLoadMoreRuns()
+			cmds = append(cmds, cmd)
+		}
+
+	case filteredRunsLoadedMsg:
+		if m.runsFetchingKey == msg.key {
+			m.runsFetchingKey, m.runsFetching = "", ""
+		}
+		if msg.err != nil {
+			m.message = errorMessage(actionLoadRuns, msg.err)
+			cmds = append(cmds, clearMsg())
+		}
+		// a failed fetch, like a successful one, is repeated on the next
+		// refresh rather than straight away
+		if m.runsFetched == nil {
+			m.runsFetched = make(map[string]time.Time)
+		}
+		m.runsFetched[msg.key] = time.Now()
+		var prevID int64
+		if run := m.selectedRun(); run != nil {
+			prevID = run.ID
+		}
+		m.allRuns = updateRuns(m.allRuns, msg.runs)
+		m.refreshBranches()
+		cmd := m.applyFilter()
+		cmds = append(cmds, cmd)
+		if run := m.selectedRun(); run != nil && run.ID != prevID {
+			cmd := m.runChanged()
+			cmds = append(cmds, cmd)
 		}
 
 	case jobsLoadedMsg:
-		if msg.err == nil {
+		// drop results of a superseded fetch, or for a run that is no
+		// longer selected
+		if msg.seq != m.jobsSeq {
+			break
+		}
+		m.jobsCancel = nil
+		if run := m.selectedRun(); run == nil || run.ID != msg.runID || errors.Is(msg.err, context.Canceled) {
+			break
+		}
+		if msg.err != nil {
+			m.message = errorMessage(actionLoadJobs, msg.err)
+			cmds = append(cmds, clearMsg())
+		} else {
 			m.jobs = msg.jobs
-			if m.jobCursor >= len(m.jobs) {
+			m.artifacts, m.artifactsErr = msg.artifacts, msg.artifactsErr
+			m.deployments = msg.deployments
+			if m.jobCursor >= len(m.jobs)+len(m.artifacts) {
 				m.jobCursor = 0
 			}
 		}
 
 	case logsLoadedMsg:
+		// drop logs of a superseded fetch, or for a job that is no longer
+		// under the cursor
+		if msg.seq != m.logsSeq {
+			break
+		}
+		m.logsCancel = nil
+		if m.jobCursor >= len(m.jobs) || m.jobs[m.jobCursor].ID != msg.jobID || errors.Is(msg.err, context.Canceled) {
+			break
+		}
 		m.message = ""
-		if msg.err != nil {
-			m.message = "error loading logs: " + msg.err.Error()
+		job := m.jobs[m.jobCursor]
+		active := job.Status != types.RunStatusCompleted
+		// a job that is queued or just starting has no logs yet; it is
+		// shown empty and followed until they appear
+		if msg.err != nil && !(active && gh.IsNotFound(msg.err)) {
+			m.message = errorMessage(actionLoadLogs, msg.err)
+			break
+		}
+		m.setLogs(msg.logs, job.Steps)
+		m.logJobName = msg.jobName
+		m.screen = ScreenLogs
+		m.logRepo, m.logJobID = msg.repo, job.ID
+		m.logJobActive = active
+		if m.logJobActive {
+			cmd := m.followLogs()
+			cmds = append(cmds, cmd)
 		} else {
-			m.logs = msg.logs
-			m.logJobName = msg.jobName
-			m.logOffset = 0
-			m.screen = ScreenLogs
+			m.jumpToFirstError()
 		}
 
 	case actionResultMsg:
 		if msg.err != nil {
-			m.message = "error: " + msg.err.Error()
+			m.message = errorMessage(msg.action, msg.err)
 		} else {
 			m.message = msg.message
 		}
 		cmds = append(cmds, clearMsg(), m.loadRuns())
 
+	case artifactLoadedMsg:
+		// drop an artifact that is no longer under the cursor
+		if a := m.selectedArtifact(); a == nil || a.ID != msg.artifactID {
+			break
+		}
+		if msg.err != nil {
+			m.message = errorMessage(actionDownload, msg.err)
+			cmds = append(cmds, clearMsg())
+			break
+		}
+		m.message = ""
+		m.artifactName = msg.name
+		m.artifactEntries = msg.entries
+		m.artifactEntryCursor = 0
+		m.artifactEntryOpen = false
+		m.screen = ScreenLogs
+
+	case downloadResultMsg:
+		if msg.err != nil {
+			m.message = errorMessage(actionDownload, msg.err)
+		} else {
+			m.message = "saved " + msg.path
+		}
+		cmds = append(cmds, clearMsg())
+
+	case runDeletedMsg:
+		cmd := m.deleteProgress(msg)
+		cmds = append(cmds, cmd)
+
+	case logFollowTickMsg:
+		cmd := m.followTicked(msg)
+		cmds = append(cmds, cmd)
+
+	case logsFollowedMsg:
+		cmd := m.logsFollowed(msg)
+		cmds = append(cmds, cmd)
+
+	case workflowsLoadedMsg:
+		for repo, workflows := range msg.workflows {
+			m.repoWorkflows[repo] = workflows
+		}
+
+	case workflowToggledMsg:
+		if msg.err != nil {
+			m.message = errorMessage(actionToggle, msg.err)
+		} else {
+			m.setWorkflowState(msg.repo, msg.workflowID, msg.state)
+			m.message = stateVerb(msg.state) + " " + msg.name
+		}
+		cmds = append(cmds, clearMsg())
+
 	case dispatchResultMsg:
 		if msg.err != nil {
-			m.message = "error: " + msg.err.Error()
+			m.message = errorMessage(actionDispatch, msg.err)
 		} else {
 			m.message = msg.message
 		}
 		cmds = append(cmds, clearMsg(), m.loadRuns())
 
 	case tickMsg:
-		cmds = append(cmds, m.loadRuns(), m.tick())
+		if m.rateLimit.Exhausted(time.Time(msg)) {
+			slog.Debug("rate limit exhausted; skipping refresh", "reset", m.rateLimit.Reset)
+		} else {
+			cmds = append(cmds, m.loadRuns())
+		}
+		cmds = append(cmds, m.tick())
 
 	case clearMsgMsg:
 		m.message = ""
@@ -652,9 +1413,9 @@ func (m Model) handleBranchSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.branchSelecting = false
 		m.branchInput.Blur()
 		m.workflowCursor = 1 // land on workflowAll so next Enter goes right, not re-opens selector
-		m.applyFilter()
+		cmd := m.applyFilter()
 		m.cursor = 0
-		return m, nil
+		return m, cmd
 	case tea.KeyUp:
 		if m.branchSuggestionCursor > 0 {
 			m.branchSuggestionCursor--
@@ -675,6 +1436,37 @@ func (m Model) handleBranchSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.confirmFailed {
+		switch msg.String() {
+		case "f":
+			m.confirming = false
+			m.message = "re-running failed jobs..."
+			return m, m.rerunFailedJobs(m.confirmRepo, m.confirmID, false)
+		case "F":
+			m.confirming = false
+			m.message = "re-running failed jobs with debug..."
+			return m, m.rerunFailedJobs(m.confirmRepo, m.confirmID, true)
+		}
+	}
+	if m.confirmJobID != 0 {
+		switch msg.String() {
+		case "y":
+			m.confirming = false
+			m.message = "re-running " + m.confirmJobName + "..."
+			return m, m.rerunJob(m.confirmRepo, m.confirmJobID, false)
+		case "d":
+			m.confirming = false
+			m.message = "re-running " + m.confirmJobName + " with debug..."
+			return m, m.rerunJob(m.confirmRepo, m.confirmJobID, true)
+		case "a":
+			m.confirming = false
+			m.message = "re-running..."
+			return m, m.rerunWorkflow(m.confirmRepo, m.confirmID, false)
+		case "esc", "q":
+			m.confirming = false
+		}
+		return m, nil
+	}
 	switch msg.String() {
 	case "y":
 		m.confirming = false
@@ -691,80 +1483,87 @@ func (m Model) handleConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleDispatchConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if len(m.dispatchInputs) > 0 {
+		return m.handleDispatchForm(msg)
+	}
 	switch msg.String() {
 	case "y":
 		m.dispatchConfirming = false
 		m.message = "dispatching..."
-		return m, m.runDispatch(m.dispatchRepo, m.dispatchFile, m.dispatchRef)
+		return m, m.runDispatch(m.dispatchRepo, m.dispatchFile, m.dispatchRef, nil)
 	case "esc", "q":
 		m.dispatchConfirming = false
 	}
 	return m, nil
 }
 
+// runChanged clears job state after the selected run changes and starts
+// loading jobs for the new selection, cancelling fetches still in flight for
+// the previous one.
+func (m *Model) runChanged() tea.Cmd {
+	m.jobs = nil
+	m.artifacts, m.artifactsErr = nil, nil
+	m.deployments = nil
+	m.jobCursor = 0
+	m.cancelLogs()
+	if run := m.selectedRun(); run != nil {
+		return m.loadJobs(*run)
+	}
+	m.cancelJobs()
+	return nil
+}
+
 func (m Model) moveCursor(delta int) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
 	switch m.activePanel {
 	case panelWorkflows:
 		n := m.workflowCursor + delta
 		if n >= 0 && n <= len(m.workflows) {
 			m.workflowCursor = n
-			m.applyFilter()
+			fetch := m.applyFilter()
 			m.cursor = 0
-			m.jobs = nil
-			m.jobCursor = 0
-			if run := m.selectedRun(); run != nil {
-				return m, m.loadJobs(run.Repository.FullName, run.ID)
-			}
+			cmd = tea.Batch(m.runChanged(), fetch)
 		}
 	case panelRuns:
 		n := m.cursor + delta
 		if n >= 0 && n < len(m.filteredRuns) {
 			m.cursor = n
-			m.jobs = nil
-			m.jobCursor = 0
-			if run := m.selectedRun(); run != nil {
-				return m, m.loadJobs(run.Repository.FullName, run.ID)
-			}
+			cmd = tea.Batch(m.runChanged(), m.maybeLoadMoreRuns())
 		}
 	case panelDetail:
 		n := m.jobCursor + delta
-		if n >= 0 && n < len(m.jobs) {
+		if n >= 0 && n < len(m.jobs)+len(m.artifacts) {
 			m.jobCursor = n
+			m.cancelLogs()
 		}
 	}
-	return m, nil
+	return m, cmd
 }
 
 func (m Model) moveCursorPage(dir int) (tea.Model, tea.Cmd) {
 	const pageSize = 10
+	var cmd tea.Cmd
 	switch m.activePanel {
 	case panelWorkflows:
 		n := max(0, min(len(m.workflows), m.workflowCursor+dir*pageSize))
 		if n != m.workflowCursor {
 			m.workflowCursor = n
-			m.applyFilter()
+			fetch := m.applyFilter()
 			m.cursor = 0
-			m.jobs = nil
-			m.jobCursor = 0
-			if run := m.selectedRun(); run != nil {
-				return m, m.loadJobs(run.Repository.FullName, run.ID)
-			}
+			cmd = tea.Batch(m.runChanged(), fetch)
 		}
 	case panelRuns:
 		n := max(0, min(len(m.filteredRuns)-1, m.cursor+dir*pageSize))
 		if n != m.cursor {
 			m.cursor = n
-			m.jobs = nil
-			m.jobCursor = 0
-			if run := m.selectedRun(); run != nil {
-				return m, m.loadJobs(run.Repository.FullName, run.ID)
-			}
+			cmd = tea.Batch(m.runChanged(), m.maybeLoadMoreRuns())
 		}
 	}
-	return m, nil
+	return m, cmd
 }
 
 func (m Model) moveCursorEdge(top bool) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
 	switch m.activePanel {
 	case panelWorkflows:
 		if top {
@@ -772,56 +1571,42 @@ func (m Model) moveCursorEdge(top bool) (tea.Model, tea.Cmd) {
 		} else {
 			m.workflowCursor = len(m.workflows)
 		}
-		m.applyFilter()
+		fetch := m.applyFilter()
 		m.cursor = 0
-		m.jobs = nil
-		m.jobCursor = 0
-		if run := m.selectedRun(); run != nil {
-			return m, m.loadJobs(run.Repository.FullName, run.ID)
-		}
+		cmd = tea.Batch(m.runChanged(), fetch)
 	case panelRuns:
 		if top {
 			m.cursor = 0
 		} else {
 			m.cursor = max(0, len(m.filteredRuns)-1)
 		}
-		m.jobs = nil
-		m.jobCursor = 0
-		if run := m.selectedRun(); run != nil {
-			return m, m.loadJobs(run.Repository.FullName, run.ID)
-		}
+		cmd = tea.Batch(m.runChanged(), m.maybeLoadMoreRuns())
 	case panelDetail:
 		if top {
 			m.jobCursor = 0
 		} else {
-			m.jobCursor = max(0, len(m.jobs)-1)
+			m.jobCursor = max(0, len(m.jobs)+len(m.artifacts)-1)
 		}
+		m.cancelLogs()
 	}
-	return m, nil
+	return m, cmd
 }
 
 func (m Model) openURL() string {
 	switch m.activePanel {
 	case panelWorkflows:
-		wfName := m.selectedWorkflow()
-		if wfName == "" || wfName == workflowAll {
+		wf := m.selectedWorkflow()
+		if !wf.specific() {
 			// branch cell or "all workflows" — open repo actions page
 			if run := m.selectedRun(); run != nil {
 				return run.Repository.HTMLURL + "/actions"
 			}
 			if len(m.config.Repos) > 0 {
-				return "https://github.com/" + m.config.Repos[0] + "/actions"
+				return gh.RepoURL(m.config.Repos[0]) + "/actions"
 			}
-		} else {
+		} else if repo := m.workflowRepo(wf); repo != "" {
 			// specific workflow — open its actions/workflows page
-			for _, r := range m.allRuns {
-				if r.Name == wfName {
-					if filename, ok := m.workflowFiles[wfName]; ok {
-						return r.Repository.HTMLURL + "/actions/workflows/" + filename
-					}
-					return r.HTMLURL
-				}
-			}
+			return gh.RepoURL(repo) + "/actions/workflows/" + wf.file
 		}
 	case panelRuns:
 		if run := m.selectedRun(); run != nil {
@@ -831,6 +1616,9 @@ func (m Model) openURL() string {
 		if m.jobCursor < len(m.jobs) {
 			return m.jobs[m.jobCursor].HTMLURL
 		}
+		if a, run := m.selectedArtifact(), m.selectedRun(); a != nil && run != nil {
+			return fmt.Sprintf("%s/artifacts/%d", run.HTMLURL, a.ID)
+		}
 	}
 	return ""
 }
@@ -877,8 +1665,17 @@ func (m Model) handleMainKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if run := m.selectedRun(); run != nil {
 				job := m.jobs[m.jobCursor]
 				m.message = "loading logs..."
-				return m, m.loadLogs(run.Repository.FullName, job.ID, job.Name)
+				cmd := m.loadLogs(run.Repository.FullName, job.ID, job.Name)
+				return m, cmd
+			}
+		} else if a, run := m.selectedArtifact(), m.selectedRun(); a != nil && run != nil {
+			// or browses the selected artifact
+			if a.Expired {
+				m.message = "artifact " + a.Name + " has expired"
+				return m, clearMsg()
 			}
+			m.message = "opening " + a.Name + "..."
+			return m, m.loadArtifactZip(run.Repository.FullName, *a)
 		}
 
 	case key.Matches(msg, m.keys.Left): // move left between panels
@@ -896,6 +1693,48 @@ func (m Model) handleMainKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.confirming = true
 			m.confirmRepo = run.Repository.FullName
 			m.confirmID = run.ID
+			m.confirmFailed = run.Status == types.RunStatusCompleted && run.Conclusion == types.ConclusionFailure
+			m.confirmJobID, m.confirmJobName = 0, ""
+			if m.activePanel == panelDetail && m.jobCursor < len(m.jobs) {
+				m.confirmJobID = m.jobs[m.jobCursor].ID
+				m.confirmJobName = m.jobs[m.jobCursor].Name
+			}
+		}
+
+	case key.Matches(msg, m.keys.Download):
+		if a, run := m.selectedArtifact(), m.selectedRun(); a != nil && run != nil && m.activePanel == panelDetail {
+			if a.Expired {
+				m.message = "artifact " + a.Name + " has expired"
+				return m, clearMsg()
+			}
+			m.message = "downloading " + a.Name + "..."
+			return m, m.downloadArtifact(run.Repository.FullName, *a)
+		}
+
+	case key.Matches(msg, m.keys.Approve):
+		if run := m.selectedRun(); run != nil && run.Status == types.RunStatusWaiting {
+			return m.startReview(*run)
+		} else if run != nil && run.NeedsApproval() {
+			m.approveConfirming = true
+			m.approveRun = *run
+		}
+
+	case key.Matches(msg, m.keys.Delete):
+		if run := m.selectedRun(); run != nil && m.activePanel == panelRuns {
+			return m.startDelete([]types.WorkflowRun{*run})
+		}
+
+	case key.Matches(msg, m.keys.DeleteAll):
+		// only for a single workflow, never every run on the branch
+		if m.activePanel == panelRuns && m.selectedWorkflow().specific() && len(m.filteredRuns) > 0 {
+			return m.startDelete(append([]types.WorkflowRun(nil), m.filteredRuns...))
+		}
+
+	case key.Matches(msg, m.keys.Toggle):
+		if m.activePanel == panelWorkflows {
+			if wf := m.selectedWorkflow(); wf.specific() {
+				return m.startToggle(wf)
+			}
 		}
 
 	case key.Matches(msg, m.keys.Cancel):
@@ -906,25 +1745,13 @@ func (m Model) handleMainKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case key.Matches(msg, m.keys.Dispatch):
 		if m.activePanel == panelWorkflows {
-			if wfName := m.selectedWorkflow(); wfName != "" && wfName != workflowAll {
-				if file, ok := m.workflowFiles[wfName]; ok {
-					var repo string
-					if run := m.selectedRun(); run != nil {
-						// Derive repo from the currently visible run (already filtered by
-						// branch + workflow), so dispatch always targets the right repo.
-						repo = run.Repository.FullName
-					} else if len(m.config.Repos) == 1 {
-						// Local-only workflow (no runs yet) with a single configured repo.
-						repo = m.config.Repos[0]
-					} else {
-						m.message = "cannot dispatch: no runs for this workflow on this branch"
-						return m, clearMsg()
-					}
-					m.dispatchConfirming = true
-					m.dispatchFile = file
-					m.dispatchRef = m.selectedBranch()
-					m.dispatchRepo = repo
+			if wf := m.selectedWorkflow(); wf.specific() && wf.file != "" {
+				repo := m.workflowRepo(wf)
+				if repo == "" {
+					m.message = "cannot dispatch: no runs for this workflow on this branch"
+					return m, clearMsg()
 				}
+				return m.startDispatch(repo, wf.file, m.selectedBranch())
 			}
 		}
 
@@ -935,7 +1762,7 @@ func (m Model) handleMainKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case key.Matches(msg, m.keys.Refresh):
 		m.message = "refreshing..."
-		return m, m.loadRuns()
+		return m, tea.Batch(m.loadRuns(), m.loadWorkflows())
 	}
 
 	return m, nil
@@ -956,6 +1783,7 @@ func (m Model) handleLogSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.logQuery != "" {
 			lines := strings.Split(m.logs, "\n")
 			m.logContextLines, m.logMatchGroups = buildLogContext(lines, m.logQuery, 3)
+			m.expandMatchingGroups(m.logQuery)
 		} else {
 			m.logContextLines = nil
 			m.logMatchGroups = nil
@@ -971,27 +1799,31 @@ func (m Model) handleLogsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.logSearching {
 		return m.handleLogSearch(msg)
 	}
-
-	// Use context lines when a query is active, otherwise raw log lines.
-	var displayLen int
-	if m.logQuery != "" {
-		displayLen = len(m.logContextLines)
-	} else {
-		displayLen = len(strings.Split(m.logs, "\n"))
+	if m.artifactEntries != nil && !m.artifactEntryOpen {
+		return m.handleArtifactEntries(msg)
 	}
-	visibleLines := m.height - logViewOverhead
+
+	visibleLines := m.logVisibleLines()
 
 	switch {
 	case key.Matches(msg, m.keys.Quit):
 		return m, tea.Quit
 
 	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Left), msg.Type == tea.KeyBackspace:
+		if m.artifactEntryOpen {
+			m.closeArtifactEntry()
+			break
+		}
 		m.screen = ScreenMain
-		m.logQuery = ""
-		m.logSearching = false
-		m.logContextLines = nil
-		m.logMatchGroups = nil
-		m.logMatchIdx = 0
+		m.clearLogSearch()
+		m.stopFollowing()
+
+	case key.Matches(msg, m.keys.Follow):
+		if m.logFollowing {
+			m.logFollowing = false
+		} else if m.logJobActive {
+			return m, m.followLogs()
+		}
 
 	case key.Matches(msg, m.keys.Search):
 		m.logSearching = true
@@ -1011,38 +1843,127 @@ func (m Model) handleLogsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.logOffset = m.logMatchGroups[m.logMatchIdx]
 		}
 
-	case key.Matches(msg, m.keys.Up):
-		if m.logOffset > 0 {
-			m.logOffset--
+	case key.Matches(msg, m.keys.Colors):
+		m.logStripANSI = !m.logStripANSI
+
+	case key.Matches(msg, m.keys.Fold) && m.logQuery == "":
+		m.toggleGroup()
+
+	case key.Matches(msg, m.keys.UnfoldAll) && m.logQuery == "":
+		m.setAllGroupsExpanded(true)
+
+	case key.Matches(msg, m.keys.FoldAll) && m.logQuery == "":
+		m.setAllGroupsExpanded(false)
+
+	case key.Matches(msg, m.keys.NextStep) && m.logQuery == "":
+		if i := m.currentLogStep(); i < len(m.logSteps)-1 {
+			m.jumpToLogStep(i + 1)
 		}
 
-	case key.Matches(msg, m.keys.Down):
-		if maxOffset := displayLen - visibleLines; m.logOffset < maxOffset {
-			m.logOffset++
+	case key.Matches(msg, m.keys.PrevStep) && m.logQuery == "":
+		i := m.currentLogStep()
+		if i >= 0 && m.logRows[m.logCursor].line > m.logSteps[i].start {
+			m.jumpToLogStep(i) // to the start of the current step first
+		} else {
+			m.jumpToLogStep(i - 1)
+		}
+
+	case key.Matches(msg, m.keys.FailedStep) && m.logQuery == "":
+		if i := m.firstFailedLogStep(); i >= 0 {
+			m.jumpToLogStep(i)
+		} else {
+			m.message = "no failed step"
+			return m, clearMsg()
 		}
 
+	case key.Matches(msg, m.keys.NextIssue) && m.logQuery == "",
+		key.Matches(msg, m.keys.PrevIssue) && m.logQuery == "":
+		if !m.jumpToLogIssue(key.Matches(msg, m.keys.NextIssue)) {
+			m.message = "no errors or warnings"
+			return m, clearMsg()
+		}
+
+	case key.Matches(msg, m.keys.Up):
+		m.logFollowing = false
+		m.moveLogCursor(-1, false)
+
+	case key.Matches(msg, m.keys.Down):
+		m.moveLogCursor(1, false)
+
 	case key.Matches(msg, m.keys.PageUp):
-		m.logOffset = max(0, m.logOffset-visibleLines)
+		m.logFollowing = false
+		m.moveLogCursor(-visibleLines, true)
 
 	case key.Matches(msg, m.keys.PageDown):
-		m.logOffset = min(max(0, displayLen-visibleLines), m.logOffset+visibleLines)
+		m.moveLogCursor(visibleLines, true)
 
 	case key.Matches(msg, m.keys.HalfPageUp):
-		m.logOffset = max(0, m.logOffset-visibleLines/2)
+		m.logFollowing = false
+		m.moveLogCursor(-visibleLines/2, true)
 
 	case key.Matches(msg, m.keys.HalfPageDown):
-		m.logOffset = min(max(0, displayLen-visibleLines), m.logOffset+visibleLines/2)
+		m.moveLogCursor(visibleLines/2, true)
 
 	case key.Matches(msg, m.keys.Top):
-		m.logOffset = 0
+		m.logFollowing = false
+		m.moveLogCursor(-m.logDisplayLen(), true)
 
 	case key.Matches(msg, m.keys.Bottom):
-		m.logOffset = max(0, displayLen-visibleLines)
+		m.moveLogCursor(m.logDisplayLen(), true)
 	}
 
 	return m, nil
 }
 
+// logDisplayLen is the number of rows the log view scrolls through: the
+// search context when a query is active, otherwise the log rows.
+func (m Model) logDisplayLen() int {
+	if m.logQuery != "" {
+		return len(m.logContextLines)
+	}
+	return len(m.logRows)
+}
+
+// logVisibleLines is the number of log rows that fit on screen.
+func (m Model) logVisibleLines() int {
+	h := m.height
+	if h == 0 {
+		h = 24
+	}
+	return max(1, h-logViewOverhead)
+}
+
+// moveLogCursor moves the cursor by delta rows, scrolling the view just
+// enough to keep it on screen, or by the same delta when page is set.
+// Search results have no cursor and are scrolled instead.
+func (m *Model) moveLogCursor(delta int, page bool) {
+	n, visible := m.logDisplayLen(), m.logVisibleLines()
+	maxOffset := max(0, n-visible)
+	if m.logQuery != "" {
+		m.logOffset = max(0, min(maxOffset, m.logOffset+delta))
+		return
+	}
+	m.logCursor = max(0, min(n-1, m.logCursor+delta))
+	if page {
+		m.logOffset += delta
+	}
+	if m.logCursor < m.logOffset {
+		m.logOffset = m.logCursor
+	} else if m.logCursor >= m.logOffset+visible {
+		m.logOffset = m.logCursor - visible + 1
+	}
+	m.logOffset = max(0, min(maxOffset, m.logOffset))
+}
+
+// clearLogSearch drops the active log search and its results.
+func (m *Model) clearLogSearch() {
+	m.logQuery = ""
+	m.logSearching = false
+	m.logContextLines = nil
+	m.logMatchGroups = nil
+	m.logMatchIdx = 0
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -1,10 +1,28 @@
 package ui
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/turkosaurus/gh-ci/internal/config"
+	"github.com/turkosaurus/gh-ci/internal/gh"
+	"github.com/turkosaurus/gh-ci/internal/types"
+	"github.com/turkosaurus/gh-ci/internal/ui/keys"
 )
 
 func TestScanLocalWorkflows(t *testing.T) {
@@ -151,3 +169,1208 @@ func TestBuildLogContext(t *testing.T) {
 		}
 	})
 }
+
+func TestMergeRuns(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	run := func(id int64, repo string, age int) types.WorkflowRun {
+		return types.WorkflowRun{
+			ID:         id,
+			CreatedAt:  base.Add(-time.Duration(age) * time.Hour),
+			Repository: types.Repository{FullName: repo},
+		}
+	}
+
+	existing := []types.WorkflowRun{
+		run(2, "o/a", 1),
+		run(3, "o/a", 2),
+		run(9, "o/a", 5), // from a later page: older than anything fresh
+		run(8, "o/b", 5), // repo missing from fresh: dropped
+	}
+	fresh := []types.WorkflowRun{
+		run(1, "o/a", 0),
+		run(2, "o/a", 1),
+		run(3, "o/a", 2),
+	}
+
+	got := mergeRuns(existing, fresh)
+	var ids []int64
+	for _, r := range got {
+		ids = append(ids, r.ID)
+	}
+	require.Equal(t, []int64{1, 2, 3, 9}, ids)
+}
+
+func TestAppendRunsSkipsDuplicates(t *testing.T) {
+	existing := []types.WorkflowRun{{ID: 1}, {ID: 2}}
+	got := appendRuns(existing, []types.WorkflowRun{{ID: 2}, {ID: 3}})
+	require.Len(t, got, 3)
+	require.Equal(t, int64(3), got[2].ID)
+}
+
+func TestPollInterval(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	base := 2 * time.Second
+	reset := now.Add(10 * time.Minute)
+	tests := []struct {
+		name string
+		rl   gh.RateLimit
+		want time.Duration
+	}{
+		{"unknown quota", gh.RateLimit{}, base},
+		{"healthy quota", gh.RateLimit{Limit: 5000, Remaining: 4000, Reset: reset}, base},
+		{"reset already passed", gh.RateLimit{Limit: 5000, Remaining: 0, Reset: now.Add(-time.Second)}, base},
+		{"low quota stretches", gh.RateLimit{Limit: 5000, Remaining: 100, Reset: reset}, 10 * time.Minute / 50},
+		{"exhausted waits for reset", gh.RateLimit{Limit: 5000, Remaining: 1, Reset: reset}, 10*time.Minute + time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, pollInterval(base, tt.rl, 2, now))
+		})
+	}
+}
+
+func TestStaleJobsLoadedMsgIgnored(t *testing.T) {
+	m := Model{filteredRuns: []types.WorkflowRun{{ID: 1}}}
+
+	updated, _ := m.Update(jobsLoadedMsg{runID: 2, jobs: []types.Job{{ID: 20}}})
+	require.Empty(t, updated.(Model).jobs, "jobs for a deselected run must not be applied")
+
+	updated, _ = m.Update(jobsLoadedMsg{runID: 1, jobs: []types.Job{{ID: 10}}})
+	require.Len(t, updated.(Model).jobs, 1)
+}
+
+func TestSupersededFetchesIgnored(t *testing.T) {
+	run := types.WorkflowRun{ID: 1, Repository: types.Repository{FullName: "o/r"}}
+	m := Model{config: config.DefaultConfig(), filteredRuns: []types.WorkflowRun{run}}
+
+	m.loadJobs(run)
+	first := m.jobsSeq
+	require.Nil(t, m.refreshJobs(run), "a refresh must not cancel the fetch in flight")
+	m.loadJobs(run) // e.g. moving away and back
+	require.NotNil(t, m.jobsCancel)
+
+	updated, _ := m.Update(jobsLoadedMsg{seq: first, runID: 1, err: context.Canceled})
+	m = updated.(Model)
+	require.Empty(t, m.message, "a cancelled fetch is not an error")
+	require.NotNil(t, m.jobsCancel, "only the latest fetch clears its cancel func")
+
+	updated, _ = m.Update(jobsLoadedMsg{seq: m.jobsSeq, runID: 1, jobs: []types.Job{{ID: 10}}})
+	m = updated.(Model)
+	require.Nil(t, m.jobsCancel)
+	require.Len(t, m.jobs, 1)
+	require.NotNil(t, m.refreshJobs(run), "refreshes resume once the fetch is done")
+
+	m.loadLogs("o/r", 10, "build")
+	seq := m.logsSeq
+	m.cancelLogs() // cursor moved off the job
+	updated, _ = m.Update(logsLoadedMsg{seq: seq, jobID: 10, err: context.Canceled})
+	m = updated.(Model)
+	require.Empty(t, m.message)
+	require.Equal(t, ScreenMain, m.screen)
+}
+
+// fakeClient overrides the gh.Client methods a test needs; calling any
+// other method panics on the nil embedded interface.
+type fakeClient struct {
+	gh.Client
+	listRuns func(repo string, opts gh.RunListOptions) (gh.RunsPage, error)
+	calls    *[]string    // records mutating calls
+	artifact []byte       // served by DownloadArtifact
+	failRun  int64        // run that DeleteRun refuses to delete
+	jobs     *[]types.Job // served by GetJobs and GetJob
+	logs     *string      // served by GetJobLogs; "" is a 404, as for a queued job
+}
+
+func (f fakeClient) record(format string, args ...any) error {
+	*f.calls = append(*f.calls, fmt.Sprintf(format, args...))
+	return nil
+}
+
+func (f fakeClient) RerunWorkflow(_ context.Context, repo string, runID int64, debug bool) error {
+	return f.record("rerun %s %d debug=%t", repo, runID, debug)
+}
+
+func (f fakeClient) RerunFailedJobs(_ context.Context, repo string, runID int64, debug bool) error {
+	return f.record("rerun-failed %s %d debug=%t", repo, runID, debug)
+}
+
+func (f fakeClient) DownloadArtifact(_ context.Context, repo string, artifactID int64, w io.Writer) error {
+	data := []byte("zip")
+	if f.artifact != nil {
+		data = f.artifact
+	}
+	if err := f.record("download %s %d", repo, artifactID); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+func (f fakeClient) ReviewPendingDeployments(_ context.Context, repo string, runID int64, envIDs []int64, approve bool, comment string) error {
+	return f.record("review %s %d %v approve=%t %q", repo, runID, envIDs, approve, comment)
+}
+
+func (f fakeClient) ApproveRun(_ context.Context, repo string, runID int64) error {
+	return f.record("approve %s %d", repo, runID)
+}
+
+func (f fakeClient) RerunJob(_ context.Context, repo string, jobID int64, debug bool) error {
+	return f.record("rerun-job %s %d debug=%t", repo, jobID, debug)
+}
+
+func (f fakeClient) EnableWorkflow(_ context.Context, repo string, workflowID int64) error {
+	return f.record("enable %s %d", repo, workflowID)
+}
+
+func (f fakeClient) DisableWorkflow(_ context.Context, repo string, workflowID int64) error {
+	return f.record("disable %s %d", repo, workflowID)
+}
+
+func (f fakeClient) DeleteRun(_ context.Context, repo string, runID int64) error {
+	f.record("delete %s %d", repo, runID)
+	if runID == f.failRun {
+		return &gh.APIError{StatusCode: 403, Message: "Resource not accessible by integration"}
+	}
+	return nil
+}
+
+func (f fakeClient) DeleteRunLogs(_ context.Context, repo string, runID int64) error {
+	return f.record("delete-logs %s %d", repo, runID)
+}
+
+func (f fakeClient) GetJobs(_ context.Context, repo string, runID int64) ([]types.Job, error) {
+	return *f.jobs, nil
+}
+
+func (f fakeClient) GetJob(_ context.Context, repo string, jobID int64) (types.Job, error) {
+	for _, j := range *f.jobs {
+		if j.ID == jobID {
+			return j, nil
+		}
+	}
+	return types.Job{}, &gh.APIError{StatusCode: 404, Message: "Not Found"}
+}
+
+func (f fakeClient) GetJobLogs(_ context.Context, repo string, jobID int64) (string, error) {
+	if *f.logs == "" {
+		return "", &gh.APIError{StatusCode: 404, Message: "Not Found"}
+	}
+	return *f.logs, nil
+}
+
+func (f fakeClient) ListWorkflowRuns(_ context.Context, repo string, opts gh.RunListOptions) (gh.RunsPage, error) {
+	return f.listRuns(repo, opts)
+}
+
+func (f fakeClient) ListWorkflowRunsForWorkflow(_ context.Context, repo, workflowFile string, opts gh.RunListOptions) (gh.RunsPage, error) {
+	return f.listRuns(repo+"/"+workflowFile, opts)
+}
+
+func (f fakeClient) RateLimit() gh.RateLimit {
+	return gh.RateLimit{}
+}
+
+func TestLoadRunsPartialFailure(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Repos = []string{"o/a", "o/bad", "o/c"}
+	m := Model{config: cfg, runPages: map[string]int{}}
+	m.client = fakeClient{listRuns: func(repo string, _ gh.RunListOptions) (gh.RunsPage, error) {
+		if repo == "o/bad" {
+			return gh.RunsPage{}, errors.New("boom")
+		}
+		return gh.RunsPage{Runs: []types.WorkflowRun{{ID: int64(len(repo)), Repository: types.Repository{FullName: repo}}}}, nil
+	}}
+
+	msg := m.loadRuns()().(runsLoadedMsg)
+	require.Len(t, msg.runs, 2)
+	require.Equal(t, "o/a", msg.runs[0].Repository.FullName, "results keep config order")
+	require.Equal(t, "o/c", msg.runs[1].Repository.FullName)
+	require.Contains(t, msg.repoErrs, "o/bad")
+
+	// runs previously loaded for the failing repo survive the refresh
+	m.allRuns = []types.WorkflowRun{{ID: 99, Repository: types.Repository{FullName: "o/bad"}}}
+	updated, _ := m.Update(msg)
+	got := updated.(Model)
+	require.Len(t, got.allRuns, 3)
+	require.Contains(t, got.repoErrors, "o/bad")
+	require.Contains(t, got.message, "o/bad")
+}
+
+func TestParseDispatchInputs(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want []types.DispatchInput
+	}{
+		{
+			name: "string trigger",
+			yaml: "on: push",
+		},
+		{
+			name: "list trigger",
+			yaml: "on: [push, workflow_dispatch]",
+		},
+		{
+			name: "dispatch without inputs",
+			yaml: "on:\n  workflow_dispatch:\n",
+		},
+		{
+			name: "inputs keep declaration order",
+			yaml: `on:
+  workflow_dispatch:
+    inputs:
+      environment:
+        description: Target
+        type: choice
+        required: true
+        options: [staging, production]
+      dry_run:
+        type: boolean
+        default: true
+      note:
+        description: Free text
+`,
+			want: []types.DispatchInput{
+				{Name: "environment", Description: "Target", Type: types.InputTypeChoice, Required: true, Options: []string{"staging", "production"}},
+				{Name: "dry_run", Type: types.InputTypeBoolean, Default: "true"},
+				{Name: "note", Description: "Free text", Type: types.InputTypeString},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var wf struct {
+				On yaml.Node `yaml:"on"`
+			}
+			require.NoError(t, yaml.Unmarshal([]byte(tt.yaml), &wf))
+			got, err := parseDispatchInputs(&wf.On)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateDispatchInputs(t *testing.T) {
+	inputs := []types.DispatchInput{
+		{Name: "env", Type: types.InputTypeChoice, Required: true, Options: []string{"staging", "production"}},
+		{Name: "count", Type: types.InputTypeNumber},
+		{Name: "note", Type: types.InputTypeString},
+	}
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]string
+		wantBad int
+	}{
+		{name: "valid, empty optional omitted", values: []string{"production", "3", ""}, want: map[string]string{"env": "production", "count": "3"}, wantBad: -1},
+		{name: "missing required", values: []string{"", "", ""}, wantBad: 0},
+		{name: "unknown choice", values: []string{"dev", "", ""}, wantBad: 0},
+		{name: "not a number", values: []string{"staging", "many", ""}, wantBad: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, bad, err := validateDispatchInputs(inputs, tt.values)
+			require.Equal(t, tt.wantBad, bad)
+			if tt.wantBad >= 0 {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// newTestModel returns a Model with the default keys and config backed by
+// client, and the calls the client records. Downloads go to a temporary
+// directory.
+func newTestModel(t *testing.T, client fakeClient) (Model, *[]string) {
+	t.Helper()
+	if client.calls == nil {
+		client.calls = new([]string)
+	}
+	cfg := config.DefaultConfig()
+	cfg.DownloadDir = t.TempDir()
+	m := Model{
+		keys:          keys.DefaultKeyMap(),
+		client:        client,
+		config:        cfg,
+		textInput:     textinput.New(),
+		deleteInput:   textinput.New(),
+		reviewComment: textinput.New(),
+	}
+	return m, client.calls
+}
+
+// namedKeys are the keys pressKey sends by name rather than as runes.
+var namedKeys = map[string]tea.KeyType{
+	"enter": tea.KeyEnter,
+	"esc":   tea.KeyEsc,
+	"up":    tea.KeyUp,
+}
+
+// pressKey sends k to m, e.g. "j" or "enter", and returns the updated model
+// and its command. Keys other than namedKeys are typed as runes.
+func pressKey(m Model, k string) (Model, tea.Cmd) {
+	if typ, ok := namedKeys[k]; ok {
+		return update(m, tea.KeyMsg{Type: typ})
+	}
+	return update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+}
+
+// update sends msg to m and returns the updated model and its command.
+func update(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	updated, cmd := m.Update(msg)
+	return updated.(Model), cmd
+}
+
+func TestRerunConfirmFromDetail(t *testing.T) {
+	run := types.WorkflowRun{ID: 1, Repository: types.Repository{FullName: "o/r"}}
+	base, calls := newTestModel(t, fakeClient{})
+	base.filteredRuns = []types.WorkflowRun{run}
+	base.jobs = []types.Job{{ID: 10, Name: "build"}, {ID: 11, Name: "test"}}
+	base.jobCursor = 1
+	base.activePanel = panelDetail
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"y", "rerun-job o/r 11 debug=false"},
+		{"d", "rerun-job o/r 11 debug=true"},
+		{"a", "rerun o/r 1 debug=false"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			*calls = nil
+			m, _ := pressKey(base, "r")
+			require.True(t, m.confirming)
+			require.Equal(t, int64(11), m.confirmJobID)
+			m, cmd := pressKey(m, tt.key)
+			require.False(t, m.confirming)
+			require.NotNil(t, cmd)
+			cmd()
+			require.Equal(t, []string{tt.want}, *calls)
+		})
+	}
+
+	// from the runs panel the whole run is re-run
+	base.activePanel = panelRuns
+	m, _ := pressKey(base, "r")
+	require.Zero(t, m.confirmJobID)
+}
+
+func TestRerunFailedJobsConfirm(t *testing.T) {
+	run := types.WorkflowRun{ID: 1, Status: types.RunStatusCompleted, Conclusion: types.ConclusionFailure,
+		Repository: types.Repository{FullName: "o/r"}}
+	m, calls := newTestModel(t, fakeClient{})
+	m.filteredRuns = []types.WorkflowRun{run}
+	m.jobs = []types.Job{
+		{ID: 10, Conclusion: types.ConclusionSuccess},
+		{ID: 11, Conclusion: types.ConclusionFailure},
+		{ID: 12, Conclusion: types.ConclusionTimedOut},
+	}
+	m.activePanel = panelRuns
+	require.Equal(t, 2, failedJobCount(m.jobs))
+
+	m, _ = pressKey(m, "r")
+	require.True(t, m.confirmFailed)
+	require.Contains(t, renderHelpBar(m, 120), "2 failed jobs")
+
+	confirmed, cmd := pressKey(m, "F")
+	require.False(t, confirmed.confirming)
+	cmd()
+	require.Equal(t, []string{"rerun-failed o/r 1 debug=true"}, *calls)
+
+	// not offered for a successful run
+	m.filteredRuns[0].Conclusion = types.ConclusionSuccess
+	m.confirming = false
+	m, _ = pressKey(m, "r")
+	require.False(t, m.confirmFailed)
+	m, _ = pressKey(m, "f")
+	require.True(t, m.confirming, "f is not a choice")
+}
+
+func TestFormatExpiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		a    types.Artifact
+		want string
+	}{
+		{types.Artifact{Expired: true}, "expired"},
+		{types.Artifact{ExpiresAt: now.Add(-time.Minute)}, "expired"},
+		{types.Artifact{ExpiresAt: now.Add(30 * time.Minute)}, "expires <1h"},
+		{types.Artifact{ExpiresAt: now.Add(5 * time.Hour)}, "expires 5h"},
+		{types.Artifact{ExpiresAt: now.Add(90 * 24 * time.Hour)}, "expires 90d"},
+		{types.Artifact{}, ""},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, formatExpiry(tt.a, now))
+	}
+}
+
+func TestDownloadArtifact(t *testing.T) {
+	m, calls := newTestModel(t, fakeClient{})
+	m.filteredRuns = []types.WorkflowRun{{ID: 1, Repository: types.Repository{FullName: "o/r"}}}
+	m.jobs = []types.Job{{ID: 10}}
+	m.artifacts = []types.Artifact{{ID: 5, Name: "cov/report"}, {ID: 6, Name: "old", Expired: true}}
+	m.activePanel = panelDetail
+
+	// the detail cursor moves from the jobs into the artifacts
+	m, _ = pressKey(m, "j")
+	require.Equal(t, int64(5), m.selectedArtifact().ID)
+	m, cmd := pressKey(m, "s")
+	require.NotNil(t, cmd)
+	msg := cmd().(downloadResultMsg)
+	require.NoError(t, msg.err)
+	require.Equal(t, filepath.Join(m.config.DownloadDir, "cov_report-5.zip"), msg.path)
+	data, err := os.ReadFile(msg.path)
+	require.NoError(t, err)
+	require.Equal(t, "zip", string(data))
+
+	// expired artifacts are not downloaded
+	m, _ = pressKey(m, "j")
+	*calls = nil
+	m, _ = pressKey(m, "s")
+	require.Empty(t, *calls)
+	require.Contains(t, m.message, "expired")
+}
+
+func TestArtifactViewer(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range map[string]string{
+		"report.txt": "line one\nFAIL: TestThing\nline three",
+		"image.png":  "\x89PNG\x00\x00binary",
+	} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = io.WriteString(w, body)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	m, calls := newTestModel(t, fakeClient{artifact: buf.Bytes()})
+	m.filteredRuns = []types.WorkflowRun{{ID: 1, Repository: types.Repository{FullName: "o/r"}}}
+	m.artifacts = []types.Artifact{{ID: 5, Name: "results"}}
+	m.activePanel = panelDetail
+	m.height = 30
+
+	_, cmd := pressKey(m, "enter")
+	require.NotNil(t, cmd)
+	m, _ = update(m, cmd())
+	require.Equal(t, ScreenLogs, m.screen)
+	require.Len(t, m.artifactEntries, 2)
+
+	// entries are listed in archive order; find each by name
+	idx := map[string]int{}
+	for i, f := range m.artifactEntries {
+		idx[f.Name] = i
+	}
+
+	m.artifactEntryCursor = idx["image.png"]
+	m, _ = pressKey(m, "enter")
+	require.False(t, m.artifactEntryOpen, "binary entries are refused")
+	require.Contains(t, m.message, "binary")
+
+	m.artifactEntryCursor = idx["report.txt"]
+	m, _ = pressKey(m, "enter")
+	require.True(t, m.artifactEntryOpen)
+	require.Contains(t, m.logs, "FAIL: TestThing")
+
+	// the log search machinery works on the entry
+	m, _ = pressKey(m, "/")
+	for _, r := range "FAIL" {
+		m, _ = pressKey(m, string(r))
+	}
+	m, _ = pressKey(m, "enter")
+	require.Len(t, m.logMatchGroups, 1)
+
+	// back returns to the entry list, then to the main screen
+	m, _ = pressKey(m, "esc")
+	require.False(t, m.artifactEntryOpen)
+	require.Empty(t, m.logQuery)
+	require.Equal(t, ScreenLogs, m.screen)
+	m, _ = pressKey(m, "esc")
+	require.Equal(t, ScreenMain, m.screen)
+	require.Nil(t, m.artifactEntries)
+
+	// artifacts too large to hold in memory are refused without a download
+	*calls = nil
+	m.artifacts[0].SizeInBytes = maxArtifactViewSize + 1
+	_, cmd = pressKey(m, "enter")
+	m, _ = update(m, cmd())
+	require.Equal(t, ScreenMain, m.screen)
+	require.Contains(t, m.message, "too large to view")
+	require.Empty(t, *calls)
+}
+
+func TestReviewPendingDeployments(t *testing.T) {
+	m, calls := newTestModel(t, fakeClient{})
+	m.filteredRuns = []types.WorkflowRun{{ID: 1, Status: types.RunStatusWaiting, Repository: types.Repository{FullName: "o/r"}}}
+	m.deployments = []types.PendingDeployment{
+		{Environment: types.Environment{ID: 7, Name: "staging"}, CurrentUserCanApprove: true},
+		{Environment: types.Environment{ID: 8, Name: "production"}},
+	}
+
+	m, _ = pressKey(m, "a")
+	require.True(t, m.reviewConfirming)
+	require.Equal(t, []types.Environment{{ID: 7, Name: "staging"}}, m.reviewEnvs, "only reviewable environments")
+	require.Contains(t, renderHelpBar(m, 120), "staging")
+
+	m, _ = pressKey(m, "x")
+	require.True(t, m.reviewDecided)
+	m, _ = pressKey(m, "no")
+	m, cmd := pressKey(m, "enter")
+	require.False(t, m.reviewConfirming)
+	cmd()
+	require.Equal(t, []string{`review o/r 1 [7] approve=false "no"`}, *calls)
+
+	// nothing to review when the user is not a required reviewer
+	m.deployments = m.deployments[1:]
+	m, _ = pressKey(m, "a")
+	require.False(t, m.reviewConfirming)
+	require.Contains(t, m.message, "not a required reviewer")
+}
+
+func TestApproveForkRun(t *testing.T) {
+	run := types.WorkflowRun{
+		ID: 3, Status: types.RunStatusCompleted, Conclusion: types.ConclusionActionRequired, HeadBranch: "patch-1",
+		Repository:     types.Repository{FullName: "o/r"},
+		HeadRepository: types.Repository{FullName: "newcomer/r"},
+		Actor:          types.User{Login: "newcomer"},
+	}
+	m, calls := newTestModel(t, fakeClient{})
+	m.filteredRuns = []types.WorkflowRun{run}
+
+	m, _ = pressKey(m, "a")
+	require.True(t, m.approveConfirming)
+	help := renderHelpBar(m, 200)
+	require.Contains(t, help, "newcomer/r")
+	require.Contains(t, help, "triggered by newcomer")
+
+	m, cmd := pressKey(m, "y")
+	require.False(t, m.approveConfirming)
+	cmd()
+	require.Equal(t, []string{"approve o/r 3"}, *calls)
+}
+
+func TestToggleWorkflow(t *testing.T) {
+	m, calls := newTestModel(t, fakeClient{})
+	m.config.Repos = []string{"o/r"}
+	m.activePanel = panelWorkflows
+	m.workflows = []workflowEntry{
+		allWorkflows,
+		{id: 1, repo: "o/r", name: "ci", file: "ci.yaml"},
+		{id: 2, repo: "o/r", name: "nightly", file: "nightly.yaml"},
+	}
+	m.workflowCursor = 3
+	m.repoWorkflows = map[string][]types.Workflow{}
+
+	m, _ = update(m, workflowsLoadedMsg{workflows: map[string][]types.Workflow{"o/r": {
+		{ID: 1, Name: "ci", State: types.WorkflowStateActive},
+		{ID: 2, Name: "nightly", State: types.WorkflowStateDisabledInactivity},
+	}}})
+	require.Contains(t, renderWorkflows(m, 30, 20), "⊘ nightly")
+
+	m, _ = pressKey(m, "e")
+	require.True(t, m.toggleConfirming)
+	require.Contains(t, renderHelpBar(m, 200), "enable nightly in o/r?")
+
+	m, cmd := pressKey(m, "y")
+	m, _ = update(m, cmd())
+	require.Equal(t, []string{"enable o/r 2"}, *calls)
+	require.Equal(t, "enabled nightly", m.message)
+	_, wf := m.findWorkflow(m.workflows[2])
+	require.False(t, wf.Disabled())
+
+	// ci is active, so the same key offers to disable it
+	m.workflowCursor = 2
+	m, _ = pressKey(m, "e")
+	m, cmd = pressKey(m, "y")
+	update(m, cmd())
+	require.Equal(t, []string{"enable o/r 2", "disable o/r 1"}, *calls)
+}
+
+func TestDeleteRun(t *testing.T) {
+	runs := []types.WorkflowRun{
+		{ID: 1, RunNumber: 41, Name: "ci", Repository: types.Repository{FullName: "o/r"}},
+		{ID: 2, RunNumber: 42, Name: "ci", Repository: types.Repository{FullName: "o/r"}},
+	}
+	m, calls := newTestModel(t, fakeClient{})
+	m.activePanel = panelRuns
+	m.allRuns = runs
+	m.filteredRuns = runs
+	m.cursor = 1
+
+	m, _ = pressKey(m, "x")
+	require.True(t, m.deleteConfirming)
+	require.Contains(t, renderHelpBar(m, 200), "delete run #42 of ci in o/r?")
+
+	// the run number must be typed exactly
+	m, _ = pressKey(m, "41")
+	m, cmd := pressKey(m, "enter")
+	require.Nil(t, cmd)
+	require.True(t, m.deleteConfirming)
+	require.Equal(t, "type 42 to confirm", m.deleteErr)
+
+	m.deleteInput.SetValue("")
+	m, _ = pressKey(m, "42")
+	m, cmd = pressKey(m, "enter")
+	require.False(t, m.deleteConfirming)
+	m, _ = update(m, cmd())
+	require.Equal(t, []string{"delete o/r 2"}, *calls)
+	require.Equal(t, "deleted run #42", m.message)
+	require.Len(t, m.allRuns, 1, "deleted run must not linger until the next refresh")
+}
+
+func TestDeleteAllShownRuns(t *testing.T) {
+	runs := []types.WorkflowRun{
+		{ID: 1, RunNumber: 7, Name: "ci", Repository: types.Repository{FullName: "o/r"}},
+		{ID: 2, RunNumber: 8, Name: "ci", Repository: types.Repository{FullName: "o/r"}},
+		{ID: 3, RunNumber: 9, Name: "ci", Repository: types.Repository{FullName: "o/r"}},
+	}
+	m, calls := newTestModel(t, fakeClient{failRun: 2})
+	m.activePanel = panelRuns
+	m.allRuns = runs
+	m.filteredRuns = runs
+	m.workflows = []workflowEntry{allWorkflows, {id: 5, repo: "o/r", name: "ci", file: "ci.yaml"}}
+	m.workflowCursor = 2
+
+	m, _ = pressKey(m, "X")
+	require.Contains(t, renderHelpBar(m, 200), "delete 3 runs of ci?")
+
+	m.deleteInput.SetValue("3")
+	m, cmd := pressKey(m, "enter")
+	require.Equal(t, "deleting run #7 (1/3)...", m.message)
+	m, cmd = update(m, cmd())
+	m, cmd = update(m, cmd())
+	require.Equal(t, "deleting run #9 (3/3)... 1 failed", m.message)
+	m, _ = update(m, cmd())
+
+	require.Equal(t, []string{"delete o/r 1", "delete o/r 2", "delete o/r 3"}, *calls)
+	require.Contains(t, m.message, "deleted 2 of 3 runs; failed #8:")
+	require.Len(t, m.allRuns, 1)
+	require.Equal(t, int64(2), m.allRuns[0].ID)
+}
+
+func TestApplyFilterFetchesQuietBranch(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Repos = []string{"o/r"}
+	var filters []gh.RunFilter
+	m := Model{
+		config: cfg,
+		client: fakeClient{listRuns: func(_ string, opts gh.RunListOptions) (gh.RunsPage, error) {
+			filters = append(filters, opts.Filter)
+			return gh.RunsPage{Runs: []types.WorkflowRun{{ID: 9, Name: "ci", HeadBranch: "quiet", Repository: types.Repository{FullName: "o/r"}}}}, nil
+		}},
+		allRuns:           []types.WorkflowRun{{ID: 1, Name: "ci", HeadBranch: "main"}},
+		availableBranches: []string{"main", "quiet"},
+		branchIdx:         1,
+		localBranch:       "quiet",
+		workflowCursor:    1,
+	}
+	cmd := m.applyFilter()
+	require.Empty(t, m.filteredRuns)
+	require.NotNil(t, cmd, "a branch with no loaded runs should be fetched from the server")
+	require.Contains(t, renderList(m, 80, 10), "loading runs for quiet")
+
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	require.Equal(t, []gh.RunFilter{{Branch: "quiet"}}, filters)
+	require.Len(t, m.filteredRuns, 1)
+	require.Equal(t, int64(9), m.filteredRuns[0].ID)
+	require.Empty(t, m.runsFetching)
+
+	// a fetched branch is refreshed once per refresh interval, however few
+	// runs it has, picking up status changes
+	require.Nil(t, m.applyFilter())
+	m.runsFetched["quiet"] = time.Now().Add(-time.Minute)
+	cmd = m.applyFilter()
+	require.NotNil(t, cmd)
+	require.Nil(t, m.applyFilter(), "not while a fetch is in flight")
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	require.Len(t, filters, 2)
+	require.Empty(t, m.runsFetching)
+}
+
+func TestFilteredRunsRetriedAfterError(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Repos = []string{"o/r"}
+	fail := true
+	m := Model{
+		config: cfg,
+		client: fakeClient{listRuns: func(_ string, opts gh.RunListOptions) (gh.RunsPage, error) {
+			if fail {
+				return gh.RunsPage{}, &gh.APIError{StatusCode: 502, Message: "Bad Gateway"}
+			}
+			return gh.RunsPage{Runs: []types.WorkflowRun{{ID: 9, Name: "ci", HeadBranch: "quiet", Status: types.RunStatusCompleted,
+				Repository: types.Repository{FullName: "o/r"}}}}, nil
+		}},
+		allRuns: []types.WorkflowRun{{ID: 9, Name: "ci", HeadBranch: "quiet", Status: types.RunStatusInProgress,
+			Repository: types.Repository{FullName: "o/r"}}},
+		availableBranches: []string{"main", "quiet"},
+		branchIdx:         1,
+		workflowCursor:    1,
+	}
+	updated, _ := m.Update(m.applyFilter()())
+	m = updated.(Model)
+	require.Contains(t, m.message, "error")
+
+	require.Nil(t, m.applyFilter(), "not retried before the next refresh")
+
+	fail = false
+	m.runsFetched["quiet"] = time.Now().Add(-time.Minute)
+	cmd := m.applyFilter()
+	require.NotNil(t, cmd, "a failed fetch is tried again")
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	require.Equal(t, types.RunStatusCompleted, m.filteredRuns[0].Status, "refetched runs replace stale ones")
+}
+
+func TestDeriveWorkflows(t *testing.T) {
+	run := func(id, workflowID int64, name, file string) types.WorkflowRun {
+		return types.WorkflowRun{ID: id, WorkflowID: workflowID, Name: name, Path: ".github/workflows/" + file,
+			Repository: types.Repository{FullName: "o/r"}}
+	}
+	runs := []types.WorkflowRun{
+		run(1, 10, "CI", "ci.yaml"),
+		run(2, 20, "CI", "ci-legacy.yaml"),
+		run(3, 10, "CI", "ci.yaml"),
+	}
+	defs := []types.WorkflowDef{{Name: "CI", File: "ci.yaml"}, {Name: "release", File: "release.yaml"}}
+
+	got := deriveWorkflows(runs, runs, defs)
+	require.Equal(t, []workflowEntry{
+		allWorkflows,
+		{id: 20, repo: "o/r", name: "CI", file: "ci-legacy.yaml"},
+		{id: 10, repo: "o/r", name: "CI", file: "ci.yaml"},
+		{name: "release", file: "release.yaml"},
+	}, got, "workflows sharing a name stay apart; only local defs without runs are added")
+
+	m := Model{workflows: got, allRuns: runs}
+	require.Equal(t, "CI (ci-legacy.yaml)", m.workflowLabel(1))
+	require.Equal(t, "release", m.workflowLabel(3))
+
+	m.workflowCursor = 3
+	m.applyFilter()
+	require.Len(t, m.filteredRuns, 2)
+	for _, r := range m.filteredRuns {
+		require.Equal(t, int64(10), r.WorkflowID)
+	}
+}
+
+func TestSelectWorkflowFetchesItsRuns(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Repos = []string{"o/r"}
+	var requests []string
+	m := Model{
+		config: cfg,
+		client: fakeClient{listRuns: func(repo string, opts gh.RunListOptions) (gh.RunsPage, error) {
+			requests = append(requests, repo+"@"+opts.Filter.Branch)
+			return gh.RunsPage{Runs: []types.WorkflowRun{{ID: 99, WorkflowID: 30, Name: "release", HeadBranch: "main",
+				Path: ".github/workflows/release.yaml", Repository: types.Repository{FullName: "o/r"}}}}, nil
+		}},
+		allRuns: []types.WorkflowRun{
+			{ID: 1, WorkflowID: 10, Name: "ci", HeadBranch: "main", Path: ".github/workflows/ci.yaml", Repository: types.Repository{FullName: "o/r"}},
+			{ID: 2, WorkflowID: 30, Name: "release", HeadBranch: "main", Path: ".github/workflows/release.yaml", Repository: types.Repository{FullName: "o/r"}},
+		},
+		availableBranches: []string{"main"},
+		runsFetched:       map[string]time.Time{"main": time.Now()}, // the branch itself is already loaded
+		workflowCursor:    1,
+	}
+	m.applyFilter()
+
+	// selecting release fetches its own runs on the selected branch
+	m.workflowCursor = 3
+	cmd := m.applyFilter()
+	require.Equal(t, "release", m.selectedWorkflow().name)
+	require.NotNil(t, cmd)
+	loaded := cmd()
+	require.Equal(t, []string{"o/r/release.yaml@main"}, requests)
+
+	updated, _ := m.Update(loaded)
+	m = updated.(Model)
+	require.Len(t, m.filteredRuns, 2)
+	require.Equal(t, "release", m.selectedWorkflow().name, "selection survives the re-derive")
+}
+
+func TestFollowLogs(t *testing.T) {
+	jobs := []types.Job{{ID: 10, RunID: 1, Name: "build", Status: types.RunStatusInProgress}}
+	logs := "one\ntwo"
+	m, _ := newTestModel(t, fakeClient{jobs: &jobs, logs: &logs})
+	m.jobs = jobs
+	m.height = logViewOverhead + 2 // two visible lines
+
+	m, cmd := update(m, logsLoadedMsg{repo: "o/r", logs: logs, jobID: 10, jobName: "build"})
+	require.True(t, m.logFollowing, "logs of an in-progress job are followed")
+	require.NotNil(t, cmd)
+
+	logs = "one\ntwo\nthree\nfour"
+	m, cmd = update(m, m.fetchFollowedLogs()())
+	require.Equal(t, logs, m.logs)
+	require.Equal(t, 2, m.logOffset, "view stays pinned to the bottom")
+	require.NotNil(t, cmd, "next poll is scheduled")
+
+	// scrolling up pauses; a tick from the paused chain does nothing
+	m, _ = pressKey(m, "up")
+	require.False(t, m.logFollowing)
+	_, cmd = update(m, logFollowTickMsg{seq: m.logFollowSeq})
+	require.Nil(t, cmd)
+	require.Contains(t, renderLogs(m), "paused")
+
+	// resuming jumps to the bottom and polls until the job completes
+	m, _ = pressKey(m, "f")
+	require.True(t, m.logFollowing)
+	_, cmd = update(m, logFollowTickMsg{seq: m.logFollowSeq - 1})
+	require.Nil(t, cmd, "ticks from before resuming are stale")
+	jobs[0].Status = types.RunStatusCompleted
+	logs += "\ndone"
+	m, cmd = update(m, m.fetchFollowedLogs()())
+	require.Nil(t, cmd)
+	require.False(t, m.logFollowing)
+	require.False(t, m.logJobActive)
+	require.Equal(t, 3, m.logOffset)
+	require.Equal(t, types.RunStatusCompleted, m.jobs[0].Status)
+	require.NotContains(t, renderLogs(m), "following")
+
+	// following stops when the job disappears, e.g. its run was deleted
+	m.logJobActive = true
+	m.followLogs()
+	jobs = nil
+	m, cmd = update(m, m.fetchFollowedLogs()())
+	require.False(t, m.logFollowing)
+	require.False(t, m.logJobActive)
+	require.Contains(t, m.message, "no longer exists")
+	require.NotNil(t, cmd, "only the message is left to clear")
+}
+
+func TestFollowQueuedJobLogs(t *testing.T) {
+	jobs := []types.Job{{ID: 10, RunID: 1, Name: "build", Status: types.RunStatusQueued}}
+	logs := ""
+	m, _ := newTestModel(t, fakeClient{jobs: &jobs, logs: &logs})
+	m.jobs = jobs
+
+	// a queued job has no logs yet; they are waited for, not reported
+	m.loadLogs("o/r", 10, "build")
+	m, cmd := update(m, logsLoadedMsg{seq: m.logsSeq, repo: "o/r", jobID: 10, jobName: "build",
+		err: &gh.APIError{StatusCode: 404, Message: "Not Found"}})
+	require.Equal(t, ScreenLogs, m.screen)
+	require.Empty(t, m.message)
+	require.True(t, m.logFollowing)
+	require.NotNil(t, cmd)
+	require.Contains(t, renderLogs(m), "waiting for the job")
+
+	m, cmd = update(m, m.fetchFollowedLogs()())
+	require.True(t, m.logFollowing, "still no logs; keep polling")
+	require.NotNil(t, cmd)
+
+	jobs[0].Status = types.RunStatusInProgress
+	logs = "one\ntwo"
+	m, _ = update(m, m.fetchFollowedLogs()())
+	require.Equal(t, logs, m.logs)
+	require.True(t, m.logFollowing)
+
+	// a completed job's missing logs are an error
+	jobs = []types.Job{{ID: 10, RunID: 1, Name: "build", Status: types.RunStatusCompleted}}
+	m.stopFollowing()
+	m.screen = ScreenMain
+	m.jobs = jobs
+	m, _ = update(m, logsLoadedMsg{seq: m.logsSeq, repo: "o/r", jobID: 10, jobName: "build",
+		err: &gh.APIError{StatusCode: 404, Message: "Not Found"}})
+	require.Equal(t, ScreenMain, m.screen)
+	require.Contains(t, m.message, "error")
+}
+
+func TestParseLogGroups(t *testing.T) {
+	lines := []string{
+		"2024-05-01T10:00:00.0000000Z ##[group]Run actions/checkout@v4",
+		"2024-05-01T10:00:00.1000000Z with: repository",
+		"2024-05-01T10:00:00.2000000Z ##[endgroup]",
+		"::group::outer",
+		"::group::inner",
+		"deep",
+		"::endgroup::",
+		"shallow",
+		"##[endgroup]",
+		"##[endgroup]", // stray: an ordinary line
+		"##[group]still running",
+		"tail",
+	}
+	groups := parseLogGroups(lines)
+	require.Equal(t, []logGroup{
+		{title: "Run actions/checkout@v4", start: 0, end: 2},
+		{title: "outer", start: 3, end: 8},
+		{title: "inner", start: 4, end: 6, depth: 1},
+		{title: "still running", start: 10, end: 11, unterminated: true},
+	}, groups)
+	require.Equal(t, []int{1, 4, 1, 1}, []int{groups[0].lines(), groups[1].lines(), groups[2].lines(), groups[3].lines()})
+
+	collapsed := buildLogRows(len(lines), groups, func(int) bool { return false })
+	require.Equal(t, []logRow{
+		{line: 0, group: 0},
+		{line: 3, group: 1},
+		{line: 9, group: -1},
+		{line: 10, group: 3},
+	}, collapsed)
+
+	expanded := buildLogRows(len(lines), groups, func(int) bool { return true })
+	require.Equal(t, []logRow{
+		{line: 0, group: 0},
+		{line: 1, group: -1, depth: 1},
+		{line: 3, group: 1},
+		{line: 4, group: 2, depth: 1},
+		{line: 5, group: -1, depth: 2},
+		{line: 7, group: -1, depth: 1},
+		{line: 9, group: -1},
+		{line: 10, group: 3},
+		{line: 11, group: -1, depth: 1},
+	}, expanded)
+}
+
+func TestFoldLogGroups(t *testing.T) {
+	m, _ := newTestModel(t, fakeClient{})
+	m.height = 30
+	m.screen = ScreenLogs
+	m.setLogs("##[group]setup\nnpm install\nadded 2000 packages\n##[endgroup]\n##[group]test\nFAIL TestThing\n##[endgroup]\ndone", nil)
+	lineNos := func(m Model) []int {
+		var lines []int
+		for _, r := range m.logRows {
+			lines = append(lines, r.line)
+		}
+		return lines
+	}
+
+	require.Equal(t, []int{0, 4, 7}, lineNos(m), "groups start collapsed")
+	require.Contains(t, renderLogs(m), "▸ setup  (2 lines)")
+
+	m, _ = pressKey(m, "enter")
+	require.Equal(t, []int{0, 1, 2, 4, 7}, lineNos(m))
+	require.Contains(t, renderLogs(m), "▾ setup")
+
+	// folding from inside a group collapses it and moves to its header
+	m, _ = pressKey(m, "j")
+	m, _ = pressKey(m, "enter")
+	require.Equal(t, []int{0, 4, 7}, lineNos(m))
+	require.Equal(t, 0, m.logCursor)
+
+	m, _ = pressKey(m, "+")
+	require.Len(t, m.logRows, 6)
+	m, _ = pressKey(m, "-")
+	require.Len(t, m.logRows, 3)
+
+	// a search expands the groups its matches are in
+	m, _ = pressKey(m, "/")
+	for _, r := range "FAIL" {
+		m, _ = pressKey(m, string(r))
+	}
+	m, _ = pressKey(m, "enter")
+	require.Len(t, m.logMatchGroups, 1)
+	require.Equal(t, []int{0, 4, 5, 7}, lineNos(m))
+}
+
+func TestParseANSI(t *testing.T) {
+	red := sgrStyle{fg: "1"}
+	tests := []struct {
+		name string
+		line string
+		want []ansiSegment
+	}{
+		{"plain", "ok  pkg", []ansiSegment{{text: "ok  pkg"}}},
+		{"color and reset", "\x1b[31mFAIL\x1b[0m pkg", []ansiSegment{{text: "FAIL", style: red}, {text: " pkg"}}},
+		{"combined attributes", "\x1b[1;4;92mPASS", []ansiSegment{{text: "PASS", style: sgrStyle{fg: "10", bold: true, underline: true}}}},
+		{"256 and truecolor", "\x1b[38;5;208ma\x1b[48;2;255;0;16mb", []ansiSegment{
+			{text: "a", style: sgrStyle{fg: "208"}},
+			{text: "b", style: sgrStyle{fg: "208", bg: "#ff0010"}},
+		}},
+		{"bare reset", "\x1b[31ma\x1b[mb", []ansiSegment{{text: "a", style: red}, {text: "b"}}},
+		{"other sequences dropped", "50%\x1b[K\x1b[2A done", []ansiSegment{{text: "50% done"}}},
+		{"cut off sequence", "text\x1b[3", []ansiSegment{{text: "text"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, parseANSI(tt.line))
+		})
+	}
+}
+
+func TestTruncateSegments(t *testing.T) {
+	red := sgrStyle{fg: "1"}
+	segs := []ansiSegment{{text: "FAIL", style: red}, {text: " github.com/o/r"}}
+
+	require.Equal(t, segs, truncateSegments(segs, 19), "fits")
+	require.Equal(t, []ansiSegment{{text: "FAIL", style: red}, {text: " g"}, {text: "..."}}, truncateSegments(segs, 9),
+		"cut by visible width, not by the bytes of escape sequences")
+	require.Equal(t, []ansiSegment{{text: "FA", style: red}}, truncateSegments(segs, 2))
+}
+
+func TestLogColors(t *testing.T) {
+	m, _ := newTestModel(t, fakeClient{})
+	m.height, m.width = 30, 80
+	m.screen = ScreenLogs
+	m.setLogs("\x1b[31mFAIL\x1b[0m TestThing\n"+strings.Repeat("\x1b[32mok\x1b[0m ", 40), nil)
+
+	view := renderLogs(m)
+	require.Contains(t, view, "FAIL")
+	require.NotContains(t, view, "\x1b[31mFAIL", "sequences are re-rendered, not printed raw")
+	for _, line := range strings.Split(view, "\n") {
+		if !strings.Contains(line, "ok ok") {
+			continue
+		}
+		require.True(t, strings.HasSuffix(line, "..."))
+		require.LessOrEqual(t, lipgloss.Width(line), 80, "long colored lines are cut at the visible width")
+	}
+
+	// search matches the visible text
+	m, _ = pressKey(m, "/")
+	m.textInput.SetValue("FAIL TestThing")
+	m, _ = pressKey(m, "enter")
+	require.Len(t, m.logMatchGroups, 1)
+
+	// and results are rendered like the log: colored, cut at the visible width
+	view = renderLogs(m)
+	require.Contains(t, view, "FAIL TestThing")
+	require.NotContains(t, view, "\x1b[31mFAIL")
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "ok ok") {
+			require.True(t, strings.HasSuffix(line, "..."))
+			require.LessOrEqual(t, lipgloss.Width(line), 80)
+		}
+	}
+
+	m.clearLogSearch()
+	m, _ = pressKey(m, "C")
+	require.True(t, m.logStripANSI)
+	require.Contains(t, renderLogs(m), "show colors")
+}
+
+func TestLogSteps(t *testing.T) {
+	at := func(sec int) time.Time { return time.Date(2024, 5, 1, 10, 0, sec, 0, time.UTC) }
+	steps := []types.Step{
+		{Number: 1, Name: "Set up job", Status: "completed", Conclusion: "success", StartedAt: at(0), CompletedAt: at(2)},
+		{Number: 2, Name: "Checkout", Status: "completed", Conclusion: "success", StartedAt: at(2), CompletedAt: at(7)},
+		{Number: 3, Name: "Test", Status: "completed", Conclusion: "failure", StartedAt: at(7), CompletedAt: at(10)},
+		{Number: 4, Name: "Upload", Status: "completed", Conclusion: "skipped", StartedAt: at(10), CompletedAt: at(10)},
+		{Number: 5, Name: "Complete job", Status: "completed", Conclusion: "success", StartedAt: at(10), CompletedAt: at(10)},
+	}
+	logs := strings.Join([]string{
+		"2024-05-01T10:00:00.1000000Z Current runner version: '2.317.0'",
+		"2024-05-01T10:00:01.2000000Z Complete runner setup",
+		"2024-05-01T10:00:02.1000000Z Secret source: Actions", // last line of set up, in checkout's first second
+		"2024-05-01T10:00:02.5000000Z ##[group]Run actions/checkout@v4",
+		"2024-05-01T10:00:02.6000000Z with:",
+		"2024-05-01T10:00:02.7000000Z ##[endgroup]",
+		"2024-05-01T10:00:05.0000000Z Fetching the repository",
+		"2024-05-01T10:00:07.3000000Z ##[group]Run go test ./...",
+		"2024-05-01T10:00:07.3000000Z go test ./...",
+		"2024-05-01T10:00:07.4000000Z ##[endgroup]",
+		"2024-05-01T10:00:09.0000000Z --- FAIL: TestThing",
+		"2024-05-01T10:00:10.0000000Z Cleaning up orphan processes",
+	}, "\n")
+
+	sections := splitLogSteps(strings.Split(logs, "\n"), steps)
+	var starts []int
+	for _, s := range sections {
+		starts = append(starts, s.start)
+	}
+	require.Equal(t, []int{0, 3, 7, 11}, starts, "skipped steps have no section")
+	require.Empty(t, splitLogSteps([]string{"no timestamps"}, steps[1:]))
+
+	m, _ := newTestModel(t, fakeClient{})
+	m.height = 30
+	m.screen = ScreenLogs
+	m.setLogs(logs, steps)
+	cursorLine := func(m Model) int { return m.logRows[m.logCursor].line }
+
+	require.Contains(t, renderLogs(m), "step 1/5 Set up job")
+	m, _ = pressKey(m, "]")
+	require.Equal(t, 3, cursorLine(m))
+	m, _ = pressKey(m, "j")
+	m, _ = pressKey(m, "[")
+	require.Equal(t, 3, cursorLine(m), "back to the start of the current step first")
+	m, _ = pressKey(m, "[")
+	require.Equal(t, 0, cursorLine(m))
+
+	m, _ = pressKey(m, "F")
+	require.Equal(t, 7, cursorLine(m))
+	require.Contains(t, renderLogs(m), "step 3/5 Test")
+
+	// every step's duration is shown when they fit, else only the current one
+	outline := renderStepOutline(m, 80)
+	require.Contains(t, outline, "2s")
+	require.Contains(t, outline, "5s")
+	require.Contains(t, outline, "3s")
+	outline = renderStepOutline(m, 36)
+	require.NotContains(t, outline, "5s")
+	require.Contains(t, outline, "3s")
+	require.LessOrEqual(t, lipgloss.Width(outline), 36)
+
+	m.logJobSteps[2].Conclusion = "success"
+	m.parseLogs()
+	m, _ = pressKey(m, "F")
+	require.Contains(t, renderLogs(m), "no failed step")
+}
+
+func TestLineLevel(t *testing.T) {
+	tests := []struct {
+		line string
+		want logLevel
+	}{
+		{"2024-05-01T10:00:09.0000000Z ##[error]Process completed with exit code 1.", levelError},
+		{"##[warning]Node.js 16 actions are deprecated.", levelWarning},
+		{"::error file=main.go,line=3,col=1::undefined: foo", levelError},
+		{"::warning::cache miss", levelWarning},
+		{"    --- FAIL: TestThing (0.00s)", levelError},
+		{"FAIL\tgithub.com/o/r\t0.012s", levelError},
+		{"\x1b[31mFAIL\x1b[0m", levelError},
+		{"panic: runtime error: index out of range", levelError},
+		{"ok  \tgithub.com/o/r\t0.010s", levelNone},
+		{"no errors found", levelNone},
+		{"echo ::error::", levelNone},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, lineLevel(tt.line), tt.line)
+	}
+}
+
+func TestLogIssues(t *testing.T) {
+	logs := strings.Join([]string{
+		"##[group]Run go vet ./...",
+		"go vet ./...",
+		"##[endgroup]",
+		"##[warning]deprecated input",
+		"##[group]Run go test ./...",
+		"--- FAIL: TestThing (0.00s)",
+		"FAIL\tgithub.com/o/r\t0.012s",
+		"##[endgroup]",
+		"##[error]Process completed with exit code 1.",
+	}, "\n")
+	jobs := []types.Job{{ID: 10, RunID: 1, Status: types.RunStatusCompleted, Conclusion: types.ConclusionFailure}}
+	m, _ := newTestModel(t, fakeClient{})
+	m.height = 30
+	m.jobs = jobs
+	cursorLine := func(m Model) int { return m.logRows[m.logCursor].line }
+
+	m, _ = update(m, logsLoadedMsg{repo: "o/r", logs: logs, jobID: 10})
+	require.Equal(t, 5, cursorLine(m), "opens at the first error, expanding its group")
+	view := renderLogs(m)
+	require.Contains(t, view, "✗ 3 errors ! 1 warning")
+	require.Contains(t, view, "▸ Run go vet ./...  (1 line)")
+
+	m, _ = pressKey(m, "e")
+	require.Equal(t, 6, cursorLine(m))
+	m, _ = pressKey(m, "e")
+	require.Equal(t, 8, cursorLine(m))
+	m, _ = pressKey(m, "e")
+	require.Equal(t, 3, cursorLine(m), "wraps around to the warning")
+	m, _ = pressKey(m, "E")
+	require.Equal(t, 8, cursorLine(m), "and back")
+
+	m, _ = pressKey(m, "-")
+	require.Contains(t, renderLogs(m), "▸ Run go test ./...  (2 lines, 2 errors)")
+
+	m.setLogs("all good", nil)
+	m, _ = pressKey(m, "e")
+	require.Contains(t, renderLogs(m), "no errors or warnings")
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/lipgloss"
@@ -55,10 +56,15 @@ func renderMain(m Model) string {
 		Foreground(styles.ColorSubtle).
 		Render(strings.Repeat("│\n", bodyH-1) + "│")
 
+	middle := renderList(m, runsW, bodyH)
+	if m.dispatchConfirming && len(m.dispatchInputs) > 0 {
+		middle = renderDispatchForm(m, runsW, bodyH)
+	}
+
 	body := lipgloss.JoinHorizontal(lipgloss.Top,
 		lipgloss.NewStyle().Width(workflowW).Height(bodyH).Render(renderWorkflows(m, workflowW, bodyH)),
 		sep,
-		lipgloss.NewStyle().Width(runsW).Height(bodyH).Render(renderList(m, runsW, bodyH)),
+		lipgloss.NewStyle().Width(runsW).Height(bodyH).Render(middle),
 		sep,
 		lipgloss.NewStyle().Width(detailW).Height(bodyH).Render(renderDetail(m, detailW)),
 	)
@@ -78,7 +84,32 @@ var Version string = "dev"
 
 func renderTitle(m Model, width int) string {
 	title := fmt.Sprintf("ci (%s)", Version)
-	return lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPurple).Render(title)
+	left := lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPurple).Render(title)
+	right := renderQuota(m)
+	gap := width - lipgloss.Width(left) - lipgloss.Width(right)
+	if right == "" || gap < 2 {
+		return left
+	}
+	return left + strings.Repeat(" ", gap) + right
+}
+
+// renderQuota summarises the API rate limit for the title bar, noting when
+// polling has been slowed or paused to conserve it.
+func renderQuota(m Model) string {
+	rl := m.rateLimit
+	if !rl.Known() {
+		return ""
+	}
+	now := time.Now()
+	quota := fmt.Sprintf("api %d/%d", rl.Remaining, rl.Limit)
+	switch {
+	case rl.Exhausted(now):
+		return m.styles.Error.Render(quota + "  polling paused until " + rl.Reset.Format("15:04:05"))
+	case float64(rl.Remaining) < float64(rl.Limit)*lowQuotaFraction:
+		every := m.pollInterval(now).Round(time.Second)
+		return m.styles.Duration.Render(fmt.Sprintf("%s  polling every %s", quota, every))
+	}
+	return m.styles.Dimmed.Render(quota)
 }
 
 func renderPanelHeaders(m Model, workflowW, runsW, detailW int) string {
@@ -123,8 +154,14 @@ func renderWorkflows(m Model, width, height int) string {
 
 	// ── REPO section (display only) ──────────────────────────────────────────
 	rows = append(rows, headerStyle.Render("REPO"))
-	repoDisplay := strings.Join(m.config.Repos, ", ")
-	rows = append(rows, m.styles.Repo.Render(fmt.Sprintf("%-*s", width-2, gh.TruncateString(repoDisplay, width-2))))
+	for _, repo := range m.config.Repos {
+		if _, failed := m.repoErrors[repo]; failed {
+			// keep the dashboard usable; just flag the repo that failed to refresh
+			rows = append(rows, m.styles.Error.Render(gh.PadString("✗ "+repo, width-2)))
+			continue
+		}
+		rows = append(rows, m.styles.Repo.Render(gh.PadString(repo, width-2)))
+	}
 
 	// Separator
 	rows = append(rows, m.styles.Dimmed.Render(strings.Repeat("─", width-1)))
@@ -152,7 +189,7 @@ func renderWorkflows(m Model, width, height int) string {
 			}
 		}
 	} else {
-		text := fmt.Sprintf("%-*s", width-2, gh.TruncateString(branchDisplay, width-2))
+		text := gh.PadString(branchDisplay, width-2)
 		if m.workflowCursor == 0 && active {
 			rows = append(rows, selectedStyle.Render(text))
 		} else {
@@ -173,8 +210,11 @@ func renderWorkflows(m Model, width, height int) string {
 
 	// Check if we have a filename to pin at the bottom
 	var filenameStr string
-	if wfName := m.selectedWorkflow(); wfName != "" && wfName != workflowAll {
-		filenameStr = m.workflowFiles[wfName]
+	if wf := m.selectedWorkflow(); wf.specific() {
+		filenameStr = wf.file
+		if _, registered := m.findWorkflow(wf); filenameStr != "" && stateLabel(registered) != "" {
+			filenameStr += "  " + stateLabel(registered)
+		}
 	}
 
 	branchSectionH := len(rows)
@@ -199,15 +239,22 @@ func renderWorkflows(m Model, width, height int) string {
 	endIdx := min(startIdx+workflowListH, len(m.workflows))
 
 	for i := startIdx; i < endIdx; i++ {
-		wfName := m.workflows[i]
 		selected := (i + 1) == m.workflowCursor
-		text := fmt.Sprintf("%-*s", width-2, gh.TruncateString(wfName, width-2))
+		_, wf := m.findWorkflow(m.workflows[i])
+		disabled := wf != nil && wf.Disabled()
+		label := m.workflowLabel(i)
+		if disabled {
+			label = "⊘ " + label
+		}
+		text := gh.PadString(label, width-2)
 		var row string
 		switch {
 		case selected && active:
 			row = selectedStyle.Render(text)
 		case selected:
 			row = lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPurple).Render(text)
+		case disabled:
+			row = m.styles.Dimmed.Render(text)
 		default:
 			row = m.styles.Normal.Render(text)
 		}
@@ -227,6 +274,9 @@ func renderWorkflows(m Model, width, height int) string {
 func renderList(m Model, width, height int) string {
 	active := m.activePanel == panelRuns
 
+	if len(m.filteredRuns) == 0 && m.runsFetching != "" {
+		return m.styles.Dimmed.Render("loading runs for " + m.runsFetching + "...")
+	}
 	if len(m.filteredRuns) == 0 {
 		return m.styles.Dimmed.Render("no workflow runs")
 	}
@@ -264,8 +314,15 @@ func renderList(m Model, width, height int) string {
 	}
 
 	if len(m.filteredRuns) > listH {
+		more := ""
+		switch {
+		case m.loadingMore:
+			more = "  loading more..."
+		case m.hasMoreRuns():
+			more = "+"
+		}
 		rows = append(rows, m.styles.Dimmed.Render(
-			fmt.Sprintf(" %d/%d", m.cursor+1, len(m.filteredRuns))))
+			fmt.Sprintf(" %d/%d%s", m.cursor+1, len(m.filteredRuns), more)))
 	}
 
 	return strings.Join(rows, "\n")
@@ -274,8 +331,8 @@ func renderList(m Model, width, height int) string {
 func renderRunRow(m Model, run types.WorkflowRun, selected, active bool, width, colWorkflow, colFile, colNum, colDur, colDispatched, colOk int) string {
 	icon := styles.StatusIcon(run.Status, run.Conclusion)
 	iconS := fmt.Sprintf("%-*s", colOk, icon)
-	wfS := fmt.Sprintf("%-*s", colWorkflow, gh.TruncateString(run.Name, colWorkflow))
-	fileS := fmt.Sprintf("%-*s", colFile, gh.TruncateString(m.workflowFiles[run.Name], colFile))
+	wfS := gh.PadString(run.Name, colWorkflow)
+	fileS := gh.PadString(workflowFile(run), colFile)
 	numS := fmt.Sprintf("%*s", colNum, fmt.Sprintf("#%d", run.RunNumber))
 	durS := fmt.Sprintf("%-*s", colDur, gh.FormatDuration(int64(run.Duration().Seconds())))
 	dispS := fmt.Sprintf("%-*s", colDispatched, run.CreatedAt.Format(timestampFormat))
@@ -345,9 +402,18 @@ func renderDetail(m Model, width int) string {
 	field("repo", m.styles.Repo.Render(gh.TruncateString(run.Repository.FullName, width-10)))
 	field("branch", m.styles.Branch.Render(run.HeadBranch))
 	field("commit", m.styles.Normal.Render(sha))
+	if run.IsFork() {
+		field("from", m.styles.Repo.Render(gh.TruncateString(run.HeadRepository.FullName, width-10)))
+	}
+	if run.Actor.Login != "" {
+		field("actor", m.styles.Normal.Render(gh.TruncateString(run.Actor.Login, width-10)))
+	}
 	field("status", statusStyle.Render(icon+" "+run.GetStatus())+"  "+m.styles.Duration.Render(dur))
 
 	sb.WriteString("\n")
+	if deployments := renderDeployments(m, width); deployments != "" {
+		sb.WriteString(deployments + "\n")
+	}
 
 	jobsHeaderStyle := m.styles.Dimmed
 	if active {
@@ -375,16 +441,71 @@ func renderDetail(m Model, width int) string {
 			}
 			sb.WriteString(line + "\n")
 		}
+		if artifacts := renderArtifacts(m, width, active); artifacts != "" {
+			sb.WriteString("\n" + artifacts)
+		}
 	}
 
 	return sb.String()
 }
 
+// renderRerunConfirm lists the re-run choices for the confirmation prompt,
+// which depend on whether a job is selected and whether the run failed.
+func renderRerunConfirm(m Model) string {
+	opt := func(k, desc string) string {
+		return "  " + m.styles.HelpKey.Render(k) + " " + m.styles.HelpDesc.Render(desc)
+	}
+	var sb strings.Builder
+	if m.confirmJobID != 0 {
+		sb.WriteString(m.styles.Normal.Render("re-run " + gh.TruncateString(m.confirmJobName, 30) + "?"))
+		sb.WriteString(opt("y", "this job"))
+		sb.WriteString(opt("d", "this job with debug logs"))
+		sb.WriteString(opt("a", "whole run"))
+	} else {
+		sb.WriteString(m.styles.Normal.Render("re-run?"))
+		sb.WriteString(opt("y", "normal"))
+		sb.WriteString(opt("d", "debug logs"))
+	}
+	if m.confirmFailed {
+		failed := "failed jobs"
+		if n := failedJobCount(m.jobs); n > 0 {
+			failed = fmt.Sprintf("%d failed job", n)
+			if n > 1 {
+				failed += "s"
+			}
+		}
+		sb.WriteString(opt("f", failed))
+		sb.WriteString(opt("F", failed+" with debug"))
+	}
+	sb.WriteString(opt("esc", "cancel"))
+	return sb.String()
+}
+
 func renderHelpBar(m Model, width int) string {
 	if m.confirming {
-		return m.styles.Normal.Render("re-run?") + "  " +
-			m.styles.HelpKey.Render("y") + " " + m.styles.HelpDesc.Render("normal") + "  " +
-			m.styles.HelpKey.Render("d") + " " + m.styles.HelpDesc.Render("debug logs") + "  " +
+		return renderRerunConfirm(m)
+	}
+
+	if m.reviewConfirming {
+		return renderReviewConfirm(m)
+	}
+
+	if m.approveConfirming {
+		return renderApproveConfirm(m)
+	}
+
+	if m.toggleConfirming {
+		return renderToggleConfirm(m)
+	}
+
+	if m.deleteConfirming {
+		return renderDeleteConfirm(m)
+	}
+
+	if m.dispatchConfirming && len(m.dispatchInputs) > 0 {
+		return m.styles.HelpKey.Render("tab/↑↓") + " " + m.styles.HelpDesc.Render("field") + "  " +
+			m.styles.HelpKey.Render("␣/←→") + " " + m.styles.HelpDesc.Render("toggle/choose") + "  " +
+			m.styles.HelpKey.Render("↵") + " " + m.styles.HelpDesc.Render("dispatch") + "  " +
 			m.styles.HelpKey.Render("esc") + " " + m.styles.HelpDesc.Render("cancel")
 	}
 
@@ -408,14 +529,29 @@ func renderHelpBar(m Model, width int) string {
 		if run.Status == types.RunStatusInProgress {
 			items = append(items, bindingHelp(m.styles, m.keys.Cancel))
 		}
+		if (run.Status == types.RunStatusWaiting && len(m.deployments) > 0) || run.NeedsApproval() {
+			items = append(items, bindingHelp(m.styles, m.keys.Approve))
+		}
 	}
 	if m.activePanel == panelWorkflows {
-		if wfName := m.selectedWorkflow(); wfName != "" && wfName != workflowAll {
-			if _, ok := m.workflowFiles[wfName]; ok {
+		if wf := m.selectedWorkflow(); wf.specific() {
+			if wf.file != "" {
 				items = append(items, bindingHelp(m.styles, m.keys.Dispatch))
 			}
+			if _, registered := m.findWorkflow(wf); registered != nil {
+				items = append(items, bindingHelp(m.styles, m.keys.Toggle))
+			}
 		}
 	}
+	if m.activePanel == panelRuns && m.selectedRun() != nil {
+		items = append(items, bindingHelp(m.styles, m.keys.Delete))
+		if m.selectedWorkflow().specific() {
+			items = append(items, bindingHelp(m.styles, m.keys.DeleteAll))
+		}
+	}
+	if m.activePanel == panelDetail && m.selectedArtifact() != nil {
+		items = append(items, bindingHelp(m.styles, m.keys.Download))
+	}
 	items = append(items, bindingHelp(m.styles, m.keys.Open))
 
 	left := strings.Join(items, "  ")
@@ -427,6 +563,14 @@ func renderHelpBar(m Model, width int) string {
 	return left + strings.Repeat(" ", gap) + right
 }
 
+// logTitle heads the log view: the job's logs, or a file from an artifact.
+func logTitle(m Model) string {
+	if m.artifactEntryOpen {
+		return "Artifact: " + m.logJobName
+	}
+	return "Logs: " + m.logJobName
+}
+
 func renderLogs(m Model) string {
 	w, h := m.width, m.height
 	if w == 0 {
@@ -436,6 +580,10 @@ func renderLogs(m Model) string {
 		h = 24
 	}
 
+	if m.artifactEntries != nil && !m.artifactEntryOpen {
+		return renderArtifactEntries(m, w, h)
+	}
+
 	visibleLines := h - logViewOverhead
 	maxLineW := w - 8
 	if maxLineW < 40 {
@@ -450,13 +598,13 @@ func renderLogs(m Model) string {
 		end := min(m.logOffset+visibleLines, total)
 		scrollInfo := fmt.Sprintf("%d-%d / %d", m.logOffset+1, end, total)
 		matchInfo := fmt.Sprintf("[/%s  match %d/%d]", m.logQuery, m.logMatchIdx+1, len(m.logMatchGroups))
-		header := fmt.Sprintf("Logs: %s  %s", m.logJobName, m.styles.Dimmed.Render(matchInfo))
+		header := logTitle(m) + "  " + m.styles.Dimmed.Render(matchInfo) + renderFollowStatus(m)
 		hGap := w - lipgloss.Width(header) - len(scrollInfo) - 2
 		if hGap < 1 {
 			hGap = 1
 		}
 		sb.WriteString(lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPurple).
-			Render("Logs: "+m.logJobName) + "  " + m.styles.Dimmed.Render(matchInfo) +
+			Render(logTitle(m)) + "  " + m.styles.Dimmed.Render(matchInfo) + renderFollowStatus(m) +
 			strings.Repeat(" ", hGap) + m.styles.Dimmed.Render(scrollInfo))
 		sb.WriteString("\n\n")
 
@@ -466,43 +614,51 @@ func renderLogs(m Model) string {
 				sb.WriteString("\n")
 				continue
 			}
-			text := gh.TruncateString(cl.text, maxLineW)
-			numStr := m.styles.LogLineNumber.Render(fmt.Sprintf("%5d ", cl.lineNo))
+			segs := parseANSI(cl.text)
+			if m.logStripANSI {
+				for j := range segs {
+					segs[j].style = sgrStyle{}
+				}
+			}
+			style := m.styles.Dimmed
 			if cl.isMatch {
-				sb.WriteString(numStr)
-				sb.WriteString(lipgloss.NewStyle().Bold(true).Foreground(styles.ColorYellow).Render(text))
-			} else {
-				sb.WriteString(numStr)
-				sb.WriteString(m.styles.Dimmed.Render(text))
+				style = lipgloss.NewStyle().Bold(true).Foreground(styles.ColorYellow)
 			}
+			sb.WriteString(m.styles.LogLineNumber.Render(fmt.Sprintf("%5d ", cl.lineNo)))
+			sb.WriteString(renderSegments(truncateSegments(segs, maxLineW), style))
 			sb.WriteString("\n")
 		}
 	} else if m.logQuery != "" {
 		// query active but no matches
 		sb.WriteString(lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPurple).
-			Render("Logs: " + m.logJobName))
+			Render(logTitle(m)))
 		sb.WriteString("\n\n")
 		sb.WriteString(m.styles.Dimmed.Render(fmt.Sprintf("no matches for /%s", m.logQuery)))
 		sb.WriteString("\n")
 	} else {
 		// ── normal (no filter) mode ──────────────────────────────────────────
 		logLines := strings.Split(m.logs, "\n")
-		end := min(m.logOffset+visibleLines, len(logLines))
-		scrollInfo := fmt.Sprintf("%d-%d / %d", m.logOffset+1, end, len(logLines))
-		header := fmt.Sprintf("Logs: %s", m.logJobName)
-		hGap := w - len(header) - len(scrollInfo) - 2
+		end := min(m.logOffset+visibleLines, len(m.logRows))
+		scrollInfo := fmt.Sprintf("%d-%d / %d", m.logOffset+1, end, len(m.logRows))
+		header := logTitle(m)
+		follow := renderIssueCounts(m) + renderFollowStatus(m)
+		hGap := w - len(header) - lipgloss.Width(follow) - len(scrollInfo) - 2
 		if hGap < 1 {
 			hGap = 1
 		}
-		sb.WriteString(lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPurple).
-			Render(header + strings.Repeat(" ", hGap) + scrollInfo))
-		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPurple).Render(header) + follow +
+			lipgloss.NewStyle().Bold(true).Foreground(styles.ColorPurple).Render(strings.Repeat(" ", hGap)+scrollInfo))
+		sb.WriteString("\n" + renderStepOutline(m, w) + "\n")
 
-		for i := m.logOffset; i < end; i++ {
-			line := gh.TruncateString(logLines[i], maxLineW)
-			sb.WriteString(m.styles.LogLineNumber.Render(fmt.Sprintf("%5d ", i+1)))
-			sb.WriteString(m.styles.LogLine.Render(line))
-			sb.WriteString("\n")
+		if m.logs == "" && m.logJobActive {
+			sb.WriteString(m.styles.Dimmed.Render("waiting for the job to write its logs...") + "\n")
+		} else {
+			for i := m.logOffset; i < end; i++ {
+				row := m.logRows[i]
+				sb.WriteString(m.styles.LogLineNumber.Render(fmt.Sprintf("%5d ", row.line+1)))
+				sb.WriteString(renderLogRow(m, row, logLines, maxLineW, i == m.logCursor))
+				sb.WriteString("\n")
+			}
 		}
 	}
 
@@ -525,6 +681,8 @@ func renderLogs(m Model) string {
 			bindingHelp(m.styles, m.keys.Quit),
 		}
 		sb.WriteString(m.styles.Dimmed.Render(strings.Join(helpItems, "  ")))
+	} else if m.message != "" {
+		sb.WriteString(m.styles.Dimmed.Render(m.message))
 	} else {
 		helpItems := []string{
 			bindingHelp(m.styles, m.keys.Up),
@@ -532,11 +690,114 @@ func renderLogs(m Model) string {
 			m.styles.HelpKey.Render("g/G") + " " + m.styles.HelpDesc.Render("top/bottom"),
 			m.styles.HelpKey.Render("ctrl+u/d") + " " + m.styles.HelpDesc.Render("½ page"),
 			bindingHelp(m.styles, m.keys.Search),
-			m.styles.HelpKey.Render("h/esc/⌫") + " " + m.styles.HelpDesc.Render("back"),
-			bindingHelp(m.styles, m.keys.Quit),
 		}
+		helpItems = append(helpItems, m.styles.HelpKey.Render(m.keys.Colors.Help().Key)+" "+m.styles.HelpDesc.Render(colorsHelp(m)))
+		if len(m.logGroups) > 0 {
+			helpItems = append(helpItems,
+				bindingHelp(m.styles, m.keys.Fold),
+				m.styles.HelpKey.Render(m.keys.UnfoldAll.Help().Key+"/"+m.keys.FoldAll.Help().Key)+" "+m.styles.HelpDesc.Render("expand/collapse all"),
+			)
+		}
+		if len(m.logIssues) > 0 {
+			helpItems = append(helpItems,
+				m.styles.HelpKey.Render(m.keys.NextIssue.Help().Key+"/"+m.keys.PrevIssue.Help().Key)+" "+m.styles.HelpDesc.Render("next/prev error"),
+			)
+		}
+		if len(m.logSteps) > 0 {
+			helpItems = append(helpItems,
+				m.styles.HelpKey.Render(m.keys.PrevStep.Help().Key+"/"+m.keys.NextStep.Help().Key)+" "+m.styles.HelpDesc.Render("step"),
+				bindingHelp(m.styles, m.keys.FailedStep),
+			)
+		}
+		if m.logJobActive {
+			helpItems = append(helpItems, m.styles.HelpKey.Render(m.keys.Follow.Help().Key)+" "+m.styles.HelpDesc.Render(followHelp(m)))
+		}
+		helpItems = append(helpItems,
+			m.styles.HelpKey.Render("h/esc/⌫")+" "+m.styles.HelpDesc.Render("back"),
+			bindingHelp(m.styles, m.keys.Quit),
+		)
 		sb.WriteString(m.styles.Dimmed.Render(strings.Join(helpItems, "  ")))
 	}
 
 	return sb.String()
 }
+
+// renderLogRow renders a log line indented under its groups, with its ANSI
+// colors unless they are stripped or the line is an error or warning, or a
+// group header with its fold marker and, while collapsed, how many lines
+// (and errors and warnings) it hides.
+func renderLogRow(m Model, row logRow, lines []string, maxW int, selected bool) string {
+	indent := strings.Repeat("  ", row.depth)
+	style := m.styles.LogLine
+	segs := parseANSI(lines[row.line])
+	var suffix string
+	if row.group >= 0 {
+		style = m.styles.LogGroup
+		g := m.logGroups[row.group]
+		marker := "▸ "
+		if m.groupExpanded(row.group) {
+			marker = "▾ "
+		} else {
+			suffix = fmt.Sprintf("  (%d %s%s)", g.lines(), plural(g.lines(), "line"), groupIssues(m, g))
+		}
+		segs = []ansiSegment{{text: marker + g.title}}
+	} else {
+		level := m.logIssueLevel(row.line)
+		switch level {
+		case levelError:
+			style = m.styles.LogError
+		case levelWarning:
+			style = m.styles.LogWarning
+		}
+		if m.logStripANSI || level != levelNone {
+			for i := range segs {
+				segs[i].style = sgrStyle{}
+			}
+		}
+	}
+	if selected {
+		style = m.styles.Selected
+	}
+	segs = truncateSegments(segs, max(1, maxW-len(indent)-len(suffix)))
+	return indent + renderSegments(segs, style) + m.styles.Dimmed.Render(suffix)
+}
+
+// groupIssues counts the errors and warnings a collapsed group hides, for
+// its header.
+func groupIssues(m Model, g logGroup) string {
+	errors, warnings := m.countLogIssues(g.start, g.end)
+	var s string
+	if errors > 0 {
+		s += fmt.Sprintf(", %d %s", errors, plural(errors, "error"))
+	}
+	if warnings > 0 {
+		s += fmt.Sprintf(", %d %s", warnings, plural(warnings, "warning"))
+	}
+	return s
+}
+
+// renderFollowStatus marks the log header of an in-progress job as being
+// followed or paused; it is empty once the job has completed.
+func renderFollowStatus(m Model) string {
+	switch {
+	case m.logFollowing:
+		return "  " + m.styles.StatusRunning.Render("● following")
+	case m.logJobActive:
+		return "  " + m.styles.Dimmed.Render("paused")
+	}
+	return ""
+}
+
+func colorsHelp(m Model) string {
+	if m.logStripANSI {
+		return "show colors"
+	}
+	return "strip colors"
+}
+
+func followHelp(m Model) string {
+	if m.logFollowing {
+		return "pause"
+	}
+	return "follow"
+}
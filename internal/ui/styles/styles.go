@@ -28,6 +28,7 @@ type Styles struct {
 	StatusFailure lipgloss.Style
 	StatusPending lipgloss.Style
 	StatusRunning lipgloss.Style
+	StatusAction  lipgloss.Style // waiting on a maintainer, e.g. action_required
 	Selected      lipgloss.Style
 	Normal        lipgloss.Style
 	Dimmed        lipgloss.Style
@@ -40,6 +41,9 @@ type Styles struct {
 	Duration      lipgloss.Style
 	LogLine       lipgloss.Style
 	LogLineNumber lipgloss.Style
+	LogGroup      lipgloss.Style // ##[group] header in the log view
+	LogError      lipgloss.Style // error line in the log view, e.g. ##[error] or a go test FAIL
+	LogWarning    lipgloss.Style // warning line in the log view
 	FilterActive  lipgloss.Style
 	Header        lipgloss.Style
 	Border        lipgloss.Style
@@ -71,6 +75,10 @@ func DefaultStyles() Styles {
 		StatusRunning: lipgloss.NewStyle().
 			Foreground(ColorYellow),
 
+		StatusAction: lipgloss.NewStyle().
+			Foreground(ColorOrange).
+			Bold(true),
+
 		Selected: lipgloss.NewStyle().
 			Bold(true).
 			Background(ColorBgLight).
@@ -114,6 +122,17 @@ func DefaultStyles() Styles {
 			Width(6).
 			Align(lipgloss.Right),
 
+		LogGroup: lipgloss.NewStyle().
+			Foreground(ColorCyan).
+			Bold(true),
+
+		LogError: lipgloss.NewStyle().
+			Foreground(ColorRed).
+			Bold(true),
+
+		LogWarning: lipgloss.NewStyle().
+			Foreground(ColorOrange),
+
 		FilterActive: lipgloss.NewStyle().
 			Background(ColorPurple).
 			Foreground(ColorWhite).
@@ -145,6 +164,8 @@ func StatusIcon(status, conclusion string) string {
 			return "⊘"
 		case "skipped":
 			return "⊖"
+		case "action_required":
+			return "⚑"
 		default:
 			return "?"
 		}
@@ -158,6 +179,8 @@ func StatusIcon(status, conclusion string) string {
 		return "○"
 	case "waiting":
 		return "⚇"
+	case "action_required":
+		return "⚑"
 	default:
 		return "?"
 	}
@@ -171,6 +194,8 @@ func (s Styles) StatusStyle(status, conclusion string) lipgloss.Style {
 			return s.StatusSuccess
 		case "failure":
 			return s.StatusFailure
+		case "action_required":
+			return s.StatusAction
 		default:
 			return s.StatusPending
 		}
@@ -178,6 +203,8 @@ func (s Styles) StatusStyle(status, conclusion string) lipgloss.Style {
 	switch status {
 	case "in_progress":
 		return s.StatusRunning
+	case "action_required":
+		return s.StatusAction
 	default:
 		return s.StatusPending
 	}
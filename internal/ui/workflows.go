@@ -0,0 +1,258 @@
+package ui
+
+import (
+	"log/slog"
+	"path"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/turkosaurus/gh-ci/internal/types"
+)
+
+// workflowEntry is a row of the NAME section: one workflow of one repo,
+// keyed by its WorkflowID so workflows sharing a display name are kept
+// apart. Local definitions that have never run have no ID or repo and are
+// known by file alone.
+type workflowEntry struct {
+	id   int64
+	repo string
+	name string
+	file string // e.g. "ci.yaml"
+}
+
+// allWorkflows is the first row of the NAME section, selecting every workflow.
+var allWorkflows = workflowEntry{name: workflowAll}
+
+// specific reports whether the entry is a single workflow rather than
+// allWorkflows or the zero entry.
+func (w workflowEntry) specific() bool {
+	return w.name != "" && w.name != workflowAll
+}
+
+// matches reports whether run is a run of the workflow.
+func (w workflowEntry) matches(run types.WorkflowRun) bool {
+	if w.id != 0 {
+		return run.WorkflowID == w.id && run.Repository.FullName == w.repo
+	}
+	return workflowFile(run) == w.file
+}
+
+// same reports whether two entries are the same workflow, counting a local
+// definition and the entry it becomes once its runs are loaded as one.
+func (w workflowEntry) same(o workflowEntry) bool {
+	switch {
+	case !w.specific() || !o.specific():
+		return w.name == o.name
+	case w.id != 0 && o.id != 0:
+		return w.id == o.id && w.repo == o.repo
+	}
+	return w.file != "" && w.file == o.file
+}
+
+// workflowFile returns the file name of run's workflow, e.g. "ci.yaml".
+func workflowFile(run types.WorkflowRun) string {
+	if run.Path == "" {
+		return ""
+	}
+	return path.Base(run.Path)
+}
+
+// deriveWorkflows lists the workflows of runs, plus the local definitions
+// whose file has no runs in allRuns, sorted by name and prefixed with
+// allWorkflows.
+func deriveWorkflows(runs, allRuns []types.WorkflowRun, localDefs []types.WorkflowDef) []workflowEntry {
+	type key struct {
+		repo string
+		id   int64
+	}
+	seen := map[key]bool{}
+	var workflows []workflowEntry
+	for _, r := range runs {
+		k := key{r.Repository.FullName, r.WorkflowID}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		workflows = append(workflows, workflowEntry{id: r.WorkflowID, repo: k.repo, name: r.Name, file: workflowFile(r)})
+	}
+	if len(localDefs) > 0 {
+		hasRuns := map[string]bool{}
+		for _, r := range allRuns {
+			hasRuns[workflowFile(r)] = true
+		}
+		for _, def := range localDefs {
+			if !hasRuns[def.File] {
+				workflows = append(workflows, workflowEntry{name: def.Name, file: def.File})
+			}
+		}
+	}
+	sort.Slice(workflows, func(i, j int) bool {
+		a, b := workflows[i], workflows[j]
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		if a.file != b.file {
+			return a.file < b.file
+		}
+		return a.repo < b.repo
+	})
+	return append([]workflowEntry{allWorkflows}, workflows...)
+}
+
+// workflowLabel is the NAME section text for m.workflows[i]: its name, with
+// the file, or failing that the repo, added when another row shares the name.
+func (m Model) workflowLabel(i int) string {
+	w := m.workflows[i]
+	label := w.name
+	for j, o := range m.workflows {
+		if j == i || o.name != w.name {
+			continue
+		}
+		if o.file != w.file {
+			label = w.name + " (" + w.file + ")"
+		} else if w.repo != "" {
+			return w.name + " (" + w.repo + ")"
+		}
+	}
+	return label
+}
+
+// workflowRepo is the repo a workflow's actions apply to: its own, or for a
+// local definition the single configured repo ("" when there are several).
+func (m Model) workflowRepo(w workflowEntry) string {
+	if w.repo == "" && len(m.config.Repos) == 1 {
+		return m.config.Repos[0]
+	}
+	return w.repo
+}
+
+// loadWorkflows fetches the registered workflows of every configured repo
+// for their enabled/disabled state. Repos that fail are left out of the
+// message so their previously loaded state is kept.
+func (m Model) loadWorkflows() tea.Cmd {
+	repos := m.config.Repos
+	return func() tea.Msg {
+		msg := workflowsLoadedMsg{workflows: make(map[string][]types.Workflow)}
+		for _, repo := range repos {
+			ctx, cancel := m.requestContext()
+			workflows, err := m.client.ListWorkflows(ctx, repo)
+			cancel()
+			if err != nil {
+				slog.Debug("failed to load workflows", "repo", repo, "err", err)
+				continue
+			}
+			msg.workflows[repo] = workflows
+		}
+		return msg
+	}
+}
+
+// findWorkflow returns the registered workflow for w and its repo, or nil
+// when it has not loaded. Local definitions are looked up by file in every
+// configured repo.
+func (m Model) findWorkflow(w workflowEntry) (string, *types.Workflow) {
+	repos := []string{w.repo}
+	if w.repo == "" {
+		repos = m.config.Repos
+	}
+	for _, repo := range repos {
+		workflows := m.repoWorkflows[repo]
+		for i := range workflows {
+			if (w.id != 0 && workflows[i].ID == w.id) || (w.id == 0 && path.Base(workflows[i].Path) == w.file) {
+				return repo, &workflows[i]
+			}
+		}
+	}
+	return "", nil
+}
+
+// setWorkflowState records a workflow's new state after enabling or
+// disabling it, without waiting for the next workflows fetch.
+func (m *Model) setWorkflowState(repo string, workflowID int64, state string) {
+	workflows := m.repoWorkflows[repo]
+	for i := range workflows {
+		if workflows[i].ID == workflowID {
+			workflows[i].State = state
+		}
+	}
+}
+
+// stateVerb describes the change that led to state, e.g. "disabled".
+func stateVerb(state string) string {
+	if state == types.WorkflowStateActive {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+// stateLabel describes why a disabled workflow is disabled, or "" when it
+// is active.
+func stateLabel(wf *types.Workflow) string {
+	switch {
+	case wf == nil || !wf.Disabled():
+		return ""
+	case wf.State == types.WorkflowStateDisabledInactivity:
+		return "disabled (inactivity)"
+	}
+	return "disabled"
+}
+
+// startToggle opens the confirmation to enable or disable workflow w.
+func (m Model) startToggle(w workflowEntry) (tea.Model, tea.Cmd) {
+	repo, wf := m.findWorkflow(w)
+	if wf == nil {
+		m.message = "cannot enable/disable " + w.name + ": workflow state not loaded"
+		return m, clearMsg()
+	}
+	m.toggleConfirming = true
+	m.toggleRepo = repo
+	m.toggleWorkflow = *wf
+	return m, nil
+}
+
+// handleToggleConfirm confirms enabling a disabled workflow or disabling an
+// active one.
+func (m Model) handleToggleConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y":
+		m.toggleConfirming = false
+		enable := m.toggleWorkflow.Disabled()
+		if enable {
+			m.message = "enabling " + m.toggleWorkflow.Name + "..."
+		} else {
+			m.message = "disabling " + m.toggleWorkflow.Name + "..."
+		}
+		return m, m.setWorkflowEnabled(m.toggleRepo, m.toggleWorkflow, enable)
+	case "esc", "q":
+		m.toggleConfirming = false
+	}
+	return m, nil
+}
+
+func (m Model) setWorkflowEnabled(repo string, wf types.Workflow, enable bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		msg := workflowToggledMsg{repo: repo, workflowID: wf.ID, name: wf.Name}
+		if enable {
+			msg.state = types.WorkflowStateActive
+			msg.err = m.client.EnableWorkflow(ctx, repo, wf.ID)
+		} else {
+			msg.state = types.WorkflowStateDisabledManually
+			msg.err = m.client.DisableWorkflow(ctx, repo, wf.ID)
+		}
+		return msg
+	}
+}
+
+// renderToggleConfirm is the help bar while enabling or disabling a workflow.
+func renderToggleConfirm(m Model) string {
+	verb := "disable"
+	if m.toggleWorkflow.Disabled() {
+		verb = "enable"
+	}
+	return m.styles.Normal.Render(verb+" "+m.toggleWorkflow.Name+" in "+m.toggleRepo+"?") + "  " +
+		m.styles.HelpKey.Render("y") + " " + m.styles.HelpDesc.Render(verb) + "  " +
+		m.styles.HelpKey.Render("esc") + " " + m.styles.HelpDesc.Render("cancel")
+}